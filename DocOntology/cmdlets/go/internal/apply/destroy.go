@@ -0,0 +1,96 @@
+package apply
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/client"
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/manifest"
+)
+
+// BuildDestroy computes a plan that removes every resource described by
+// docs from the registry — the inverse of Build. Doc types and countries
+// are (soft-)deleted via DeleteDocType/DeleteCountry, the same as Build's
+// --prune; schemas are deprecated rather than deleted, matching
+// BuildSchemaApply's --prune (a schema may already have data classified
+// against it). A manifest document with no matching server-side resource
+// plans a no-op rather than an error, since destroying something already
+// gone is the desired end state either way.
+func BuildDestroy(ctx context.Context, c *client.Client, docs []manifest.Document) (Plan, error) {
+	var plan Plan
+
+	for _, doc := range docs {
+		var change Change
+		var err error
+
+		switch doc.Kind {
+		case manifest.KindDocType:
+			change, err = destroyDocType(ctx, c, doc)
+		case manifest.KindCountry:
+			change, err = destroyCountry(ctx, c, doc)
+		case manifest.KindSchema:
+			change, err = destroySchema(ctx, c, doc)
+		default:
+			err = fmt.Errorf("%s: unknown kind %q", doc.Source, doc.Kind)
+		}
+		if err != nil {
+			return plan, err
+		}
+		plan.Changes = append(plan.Changes, change)
+	}
+
+	return plan, nil
+}
+
+func destroyDocType(ctx context.Context, c *client.Client, doc manifest.Document) (Change, error) {
+	code := doc.Metadata.Code
+	if _, err := c.GetDocType(ctx, code); err != nil {
+		if !client.IsNotFound(err) {
+			return Change{}, fmt.Errorf("%s: failed to look up doc type %q: %w", doc.Source, code, err)
+		}
+		return Change{Kind: manifest.KindDocType, Code: code, Action: ActionNoOp}, nil
+	}
+	return Change{
+		Kind: manifest.KindDocType, Code: code, Action: ActionPrune,
+		apply: func(ctx context.Context, c *client.Client) error { return c.DeleteDocType(ctx, code) },
+	}, nil
+}
+
+func destroyCountry(ctx context.Context, c *client.Client, doc manifest.Document) (Change, error) {
+	code := doc.Metadata.Code
+	if _, err := c.GetCountry(ctx, code); err != nil {
+		if !client.IsNotFound(err) {
+			return Change{}, fmt.Errorf("%s: failed to look up country %q: %w", doc.Source, code, err)
+		}
+		return Change{Kind: manifest.KindCountry, Code: code, Action: ActionNoOp}, nil
+	}
+	return Change{
+		Kind: manifest.KindCountry, Code: code, Action: ActionPrune,
+		apply: func(ctx context.Context, c *client.Client) error { return c.DeleteCountry(ctx, code) },
+	}, nil
+}
+
+func destroySchema(ctx context.Context, c *client.Client, doc manifest.Document) (Change, error) {
+	existing, err := findSchema(ctx, c, doc)
+	if err != nil {
+		if doc.Metadata.Code != "" && client.IsNotFound(err) {
+			return Change{Kind: manifest.KindSchema, Code: doc.Metadata.Code, Action: ActionNoOp}, nil
+		}
+		return Change{}, err
+	}
+	if existing == nil {
+		return Change{Kind: manifest.KindSchema, Code: doc.Metadata.Name, Action: ActionNoOp}, nil
+	}
+
+	publicID, status := existing.PublicID, existing.Status
+	return Change{
+		Kind: manifest.KindSchema, Code: publicID, Action: ActionPrune,
+		apply: func(ctx context.Context, c *client.Client) error {
+			if status == client.StatusDeprecated {
+				return nil
+			}
+			_, err := c.DeprecateSchema(ctx, publicID)
+			return err
+		},
+	}, nil
+}
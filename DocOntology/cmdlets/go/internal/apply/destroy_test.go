@@ -0,0 +1,67 @@
+package apply
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/client"
+)
+
+func TestDestroyDocTypeMissingIsNoOp(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(client.ErrorResponse{Error: "not found"})
+	})
+
+	change, err := destroyDocType(context.Background(), c, docTypeDoc("INV"))
+	if err != nil {
+		t.Fatalf("destroyDocType: unexpected error: %v", err)
+	}
+	if change.Action != ActionNoOp {
+		t.Errorf("got action %q, want %q", change.Action, ActionNoOp)
+	}
+}
+
+// TestDestroyDocTypeServerErrorPropagates guards against a 5xx being
+// swallowed and reported as "nothing to destroy" — a real lookup failure
+// must surface as an error instead.
+func TestDestroyDocTypeServerErrorPropagates(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(client.ErrorResponse{Error: "boom"})
+	})
+
+	_, err := destroyDocType(context.Background(), c, docTypeDoc("INV"))
+	if err == nil {
+		t.Fatal("destroyDocType: expected an error from a 500 response, got nil")
+	}
+}
+
+func TestDestroyCountryMissingIsNoOp(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(client.ErrorResponse{Error: "not found"})
+	})
+
+	change, err := destroyCountry(context.Background(), c, countryDoc("PT"))
+	if err != nil {
+		t.Fatalf("destroyCountry: unexpected error: %v", err)
+	}
+	if change.Action != ActionNoOp {
+		t.Errorf("got action %q, want %q", change.Action, ActionNoOp)
+	}
+}
+
+func TestDestroyCountryServerErrorPropagates(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(client.ErrorResponse{Error: "boom"})
+	})
+
+	_, err := destroyCountry(context.Background(), c, countryDoc("PT"))
+	if err == nil {
+		t.Fatal("destroyCountry: expected an error from a 500 response, got nil")
+	}
+}
@@ -0,0 +1,333 @@
+// Package apply reconciles a set of manifest.Document desired-state
+// descriptions against the Schema Registry API: creating missing resources,
+// updating drifted ones, and (optionally) pruning resources absent from the
+// manifest set.
+package apply
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/client"
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/manifest"
+)
+
+// Action classifies what a planned change will do.
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionPrune  Action = "prune"
+	ActionNoOp   Action = "noop"
+)
+
+// Change is a single planned reconciliation step, with enough context to
+// print a diff and, later, execute it.
+type Change struct {
+	Kind   manifest.Kind
+	Code   string
+	Action Action
+	Before map[string]interface{}
+	After  map[string]interface{}
+
+	apply func(ctx context.Context, c *client.Client) error
+}
+
+// Plan is an ordered set of changes computed against the current server
+// state. Building a Plan never mutates the server; call Execute to apply it.
+type Plan struct {
+	Changes []Change
+}
+
+// HasChanges reports whether the plan does anything beyond no-ops.
+func (p Plan) HasChanges() bool {
+	for _, c := range p.Changes {
+		if c.Action != ActionNoOp {
+			return true
+		}
+	}
+	return false
+}
+
+// Execute applies every non-no-op change in order, stopping at the first
+// error.
+func (p Plan) Execute(ctx context.Context, c *client.Client) error {
+	for _, change := range p.Changes {
+		if change.Action == ActionNoOp || change.apply == nil {
+			continue
+		}
+		if err := change.apply(ctx, c); err != nil {
+			return fmt.Errorf("%s %s %q: %w", change.Action, change.Kind, change.Code, err)
+		}
+	}
+	return nil
+}
+
+// Options configures how a Plan is built.
+type Options struct {
+	// Prune (soft-)deletes every server-side doc type and country absent
+	// from the manifest set, whether or not the manifest describes any
+	// resource of that kind. Schemas are not pruned by Build. Selector is
+	// not read by Build, so pruning is not scoped by customer-id.
+	Prune    bool
+	Selector string
+}
+
+// Build computes the reconciliation plan for a set of manifest documents
+// against the current state of the registry. It never calls a mutating API
+// method itself — that's deferred to Plan.Execute.
+func Build(ctx context.Context, c *client.Client, docs []manifest.Document, opts Options) (Plan, error) {
+	var plan Plan
+
+	var docTypeCodes, countryCodes map[string]bool
+	if opts.Prune {
+		docTypeCodes = map[string]bool{}
+		countryCodes = map[string]bool{}
+	}
+
+	for _, doc := range docs {
+		var change Change
+		var err error
+
+		switch doc.Kind {
+		case manifest.KindDocType:
+			change, err = planDocType(ctx, c, doc)
+			if docTypeCodes != nil {
+				docTypeCodes[doc.Metadata.Code] = true
+			}
+		case manifest.KindCountry:
+			change, err = planCountry(ctx, c, doc)
+			if countryCodes != nil {
+				countryCodes[doc.Metadata.Code] = true
+			}
+		case manifest.KindSchema:
+			change, err = planSchema(ctx, c, doc)
+		default:
+			err = fmt.Errorf("%s: unknown kind %q", doc.Source, doc.Kind)
+		}
+		if err != nil {
+			return plan, err
+		}
+		plan.Changes = append(plan.Changes, change)
+	}
+
+	if opts.Prune {
+		pruned, err := planPrune(ctx, c, docTypeCodes, countryCodes)
+		if err != nil {
+			return plan, err
+		}
+		plan.Changes = append(plan.Changes, pruned...)
+	}
+
+	return plan, nil
+}
+
+func planDocType(ctx context.Context, c *client.Client, doc manifest.Document) (Change, error) {
+	code := doc.Metadata.Code
+	existing, err := c.GetDocType(ctx, code)
+	after := specMap(doc.Spec)
+
+	if err != nil {
+		if !client.IsNotFound(err) {
+			return Change{}, fmt.Errorf("%s: failed to look up doc type %q: %w", doc.Source, code, err)
+		}
+		return Change{
+			Kind: manifest.KindDocType, Code: code, Action: ActionCreate, After: after,
+			apply: func(ctx context.Context, c *client.Client) error {
+				_, err := c.CreateDocType(ctx, &client.CreateDocTypeRequest{
+					Code:        code,
+					Name:        stringField(doc.Spec, "name"),
+					Description: stringPtrField(doc.Spec, "description"),
+				})
+				return err
+			},
+		}, nil
+	}
+
+	before := map[string]interface{}{"name": existing.Name, "description": ptrVal(existing.Description), "isActive": existing.IsActive}
+	if equalJSON(before, after) {
+		return Change{Kind: manifest.KindDocType, Code: code, Action: ActionNoOp, Before: before, After: after}, nil
+	}
+	return Change{
+		Kind: manifest.KindDocType, Code: code, Action: ActionUpdate, Before: before, After: after,
+		apply: func(ctx context.Context, c *client.Client) error {
+			name := stringField(doc.Spec, "name")
+			desc := stringPtrField(doc.Spec, "description")
+			_, err := c.UpdateDocType(ctx, code, &client.UpdateDocTypeRequest{Name: &name, Description: desc})
+			return err
+		},
+	}, nil
+}
+
+func planCountry(ctx context.Context, c *client.Client, doc manifest.Document) (Change, error) {
+	code := doc.Metadata.Code
+	existing, err := c.GetCountry(ctx, code)
+	after := specMap(doc.Spec)
+
+	if err != nil {
+		if !client.IsNotFound(err) {
+			return Change{}, fmt.Errorf("%s: failed to look up country %q: %w", doc.Source, code, err)
+		}
+		return Change{
+			Kind: manifest.KindCountry, Code: code, Action: ActionCreate, After: after,
+			apply: func(ctx context.Context, c *client.Client) error {
+				_, err := c.CreateCountry(ctx, &client.CreateCountryRequest{Code: code, Name: stringField(doc.Spec, "name")})
+				return err
+			},
+		}, nil
+	}
+
+	before := map[string]interface{}{"name": existing.Name, "isActive": existing.IsActive}
+	if equalJSON(before, after) {
+		return Change{Kind: manifest.KindCountry, Code: code, Action: ActionNoOp, Before: before, After: after}, nil
+	}
+	return Change{
+		Kind: manifest.KindCountry, Code: code, Action: ActionUpdate, Before: before, After: after,
+		apply: func(ctx context.Context, c *client.Client) error {
+			name := stringField(doc.Spec, "name")
+			_, err := c.UpdateCountry(ctx, code, &client.UpdateCountryRequest{Name: &name})
+			return err
+		},
+	}, nil
+}
+
+func planSchema(ctx context.Context, c *client.Client, doc manifest.Document) (Change, error) {
+	name := doc.Metadata.Name
+	docType := doc.Metadata.DocType
+	country := doc.Metadata.Country
+
+	opts := &client.ListSchemasOptions{DocType: &docType, Limit: 100}
+	if country != "" {
+		opts.Country = &country
+	}
+	page, err := c.ListSchemas(ctx, opts)
+	if err != nil {
+		return Change{}, fmt.Errorf("failed to search for schema %q: %w", name, err)
+	}
+
+	content, _ := doc.Spec["content"].(map[string]interface{})
+	after := map[string]interface{}{"content": content}
+
+	var existing *client.SchemaWithRelations
+	for _, s := range page.Data {
+		if s.Name == name {
+			found := s
+			existing = &found
+			break
+		}
+	}
+
+	if existing == nil {
+		return Change{
+			Kind: manifest.KindSchema, Code: name, Action: ActionCreate, After: after,
+			apply: func(ctx context.Context, c *client.Client) error {
+				_, err := c.CreateSchema(ctx, &client.CreateSchemaRequest{
+					Name:        name,
+					DocTypeCode: docType,
+					CountryCode: optionalString(country),
+					Content:     content,
+				})
+				return err
+			},
+		}, nil
+	}
+
+	before := map[string]interface{}{"content": existing.Content}
+	if equalJSON(before, after) {
+		return Change{Kind: manifest.KindSchema, Code: existing.PublicID, Action: ActionNoOp, Before: before, After: after}, nil
+	}
+	publicID := existing.PublicID
+	return Change{
+		Kind: manifest.KindSchema, Code: publicID, Action: ActionUpdate, Before: before, After: after,
+		apply: func(ctx context.Context, c *client.Client) error {
+			_, err := c.UpdateSchema(ctx, publicID, &client.UpdateSchemaRequest{Content: content})
+			return err
+		},
+	}, nil
+}
+
+// planPrune finds server-side doc-types/countries absent from the manifest
+// set and schedules their (soft) deletion. A nil map skips that kind; Build
+// passes non-nil maps for both kinds whenever pruning is on.
+func planPrune(ctx context.Context, c *client.Client, managedDocTypes, managedCountries map[string]bool) ([]Change, error) {
+	var changes []Change
+
+	if managedDocTypes != nil {
+		docTypes, err := c.ListDocTypes(ctx, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list doc types for pruning: %w", err)
+		}
+		for _, dt := range docTypes {
+			if managedDocTypes[dt.Code] {
+				continue
+			}
+			code := dt.Code
+			changes = append(changes, Change{
+				Kind: manifest.KindDocType, Code: code, Action: ActionPrune,
+				apply: func(ctx context.Context, c *client.Client) error { return c.DeleteDocType(ctx, code) },
+			})
+		}
+	}
+
+	if managedCountries != nil {
+		countries, err := c.ListCountries(ctx, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list countries for pruning: %w", err)
+		}
+		for _, country := range countries {
+			if managedCountries[country.Code] {
+				continue
+			}
+			code := country.Code
+			changes = append(changes, Change{
+				Kind: manifest.KindCountry, Code: code, Action: ActionPrune,
+				apply: func(ctx context.Context, c *client.Client) error { return c.DeleteCountry(ctx, code) },
+			})
+		}
+	}
+
+	return changes, nil
+}
+
+func specMap(spec map[string]interface{}) map[string]interface{} {
+	out := map[string]interface{}{}
+	for k, v := range spec {
+		out[k] = v
+	}
+	return out
+}
+
+func stringField(spec map[string]interface{}, key string) string {
+	s, _ := spec[key].(string)
+	return s
+}
+
+func stringPtrField(spec map[string]interface{}, key string) *string {
+	s, ok := spec[key].(string)
+	if !ok {
+		return nil
+	}
+	return &s
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func ptrVal(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func equalJSON(a, b map[string]interface{}) bool {
+	aj, _ := json.Marshal(a)
+	bj, _ := json.Marshal(b)
+	return string(aj) == string(bj)
+}
@@ -0,0 +1,105 @@
+package apply
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/client"
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/config"
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/manifest"
+)
+
+// newTestClient builds a client.Client pointed at a test server, with
+// retries disabled so a 500 response comes straight back instead of being
+// retried (500 isn't in the retry set anyway, but this keeps intent clear).
+func newTestClient(t *testing.T, handler http.HandlerFunc) *client.Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	c, err := client.New(&config.Config{APIBaseURL: srv.URL, Timeout: 5}, client.WithMaxRetries(0))
+	if err != nil {
+		t.Fatalf("client.New: %v", err)
+	}
+	return c
+}
+
+func docTypeDoc(code string) manifest.Document {
+	return manifest.Document{
+		Kind:     manifest.KindDocType,
+		Metadata: manifest.Metadata{Code: code},
+		Spec:     map[string]interface{}{"name": "Invoice"},
+		Source:   "test.yaml",
+	}
+}
+
+// TestPlanDocTypeMissingIsCreate is the expected-absence path: a 404 from
+// GetDocType means the doc type genuinely doesn't exist yet.
+func TestPlanDocTypeMissingIsCreate(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(client.ErrorResponse{Error: "not found"})
+	})
+
+	change, err := planDocType(context.Background(), c, docTypeDoc("INV"))
+	if err != nil {
+		t.Fatalf("planDocType: unexpected error: %v", err)
+	}
+	if change.Action != ActionCreate {
+		t.Errorf("got action %q, want %q", change.Action, ActionCreate)
+	}
+}
+
+// TestPlanDocTypeServerErrorPropagates is the regression case: a transient
+// 500 must not be treated as "doesn't exist" and silently turned into a
+// create plan — it must be propagated as an error.
+func TestPlanDocTypeServerErrorPropagates(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(client.ErrorResponse{Error: "boom"})
+	})
+
+	_, err := planDocType(context.Background(), c, docTypeDoc("INV"))
+	if err == nil {
+		t.Fatal("planDocType: expected an error from a 500 response, got nil")
+	}
+}
+
+func countryDoc(code string) manifest.Document {
+	return manifest.Document{
+		Kind:     manifest.KindCountry,
+		Metadata: manifest.Metadata{Code: code},
+		Spec:     map[string]interface{}{"name": "Portugal"},
+		Source:   "test.yaml",
+	}
+}
+
+func TestPlanCountryMissingIsCreate(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(client.ErrorResponse{Error: "not found"})
+	})
+
+	change, err := planCountry(context.Background(), c, countryDoc("PT"))
+	if err != nil {
+		t.Fatalf("planCountry: unexpected error: %v", err)
+	}
+	if change.Action != ActionCreate {
+		t.Errorf("got action %q, want %q", change.Action, ActionCreate)
+	}
+}
+
+func TestPlanCountryServerErrorPropagates(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(client.ErrorResponse{Error: "boom"})
+	})
+
+	_, err := planCountry(context.Background(), c, countryDoc("PT"))
+	if err == nil {
+		t.Fatal("planCountry: expected an error from a 500 response, got nil")
+	}
+}
@@ -0,0 +1,327 @@
+package apply
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/client"
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/manifest"
+)
+
+// ActionTransition is a schema-apply-only action: the schema's content
+// already matches the manifest, but its status needs to move to
+// spec.targetStatus.
+const ActionTransition Action = "transition"
+
+// SchemaOptions configures BuildSchemaApply, the schema-only reconciliation
+// used by `schemas apply` — richer than the generic, multi-kind Build:
+// customerId-aware matching, an explicit publicId override, targetStatus
+// lifecycle transitions, and drift detection via a recorded manifest hash.
+type SchemaOptions struct {
+	// Prune deprecates managed schemas absent from the manifest set, scoped
+	// by Selector ("customer-id=<value>") so a partial manifest can't reach
+	// outside its intended blast radius. Unlike the generic Build's prune
+	// (which deletes doc types/countries), schema pruning deprecates rather
+	// than deletes, since a schema may already have data classified against
+	// it.
+	Prune    bool
+	Selector string
+}
+
+// manifestHashMarker prefixes the last-applied-manifest content hash that
+// BuildSchemaApply appends to a schema's Description, so a later apply can
+// tell a schema that's drifted out-of-band (edited directly against the API
+// since the last apply) from one it still fully owns.
+const manifestHashMarker = "\n\n[schemactl:manifest-hash] "
+
+// BuildSchemaApply computes a reconciliation plan for Schema-kind manifest
+// documents only. It never calls a mutating API method itself — that's
+// deferred to Plan.Execute, same as Build.
+func BuildSchemaApply(ctx context.Context, c *client.Client, docs []manifest.Document, opts SchemaOptions) (Plan, error) {
+	var plan Plan
+	managed := map[string]bool{}
+
+	var customerID string
+	if opts.Prune {
+		var err error
+		customerID, err = parseCustomerSelector(opts.Selector)
+		if err != nil {
+			return plan, err
+		}
+	}
+
+	for _, doc := range docs {
+		if doc.Kind != manifest.KindSchema {
+			return plan, fmt.Errorf("%s: schemas apply only accepts kind: Schema manifests, got %q", doc.Source, doc.Kind)
+		}
+
+		changes, publicID, err := planSchemaApply(ctx, c, doc)
+		if err != nil {
+			return plan, err
+		}
+		if publicID != "" {
+			managed[publicID] = true
+		}
+		plan.Changes = append(plan.Changes, changes...)
+	}
+
+	if opts.Prune {
+		pruned, err := planSchemaPrune(ctx, c, managed, customerID)
+		if err != nil {
+			return plan, err
+		}
+		plan.Changes = append(plan.Changes, pruned...)
+	}
+
+	return plan, nil
+}
+
+// planSchemaApply plans the reconciliation of a single Schema manifest,
+// returning up to two changes (a content create/update/no-op, and a status
+// transition) plus the matched schema's publicId (empty if none matched, so
+// the caller can't mistake "not found" for a managed schema when pruning).
+func planSchemaApply(ctx context.Context, c *client.Client, doc manifest.Document) ([]Change, string, error) {
+	targetStatus := stringField(doc.Spec, "targetStatus")
+	if targetStatus != "" {
+		switch client.Status(targetStatus) {
+		case client.StatusDraft, client.StatusActive, client.StatusDeprecated:
+		default:
+			return nil, "", fmt.Errorf("%s: invalid targetStatus %q (expected draft, active, or deprecated)", doc.Source, targetStatus)
+		}
+	}
+
+	existing, err := findSchema(ctx, c, doc)
+	if err != nil {
+		return nil, "", err
+	}
+
+	name := doc.Metadata.Name
+	docType := doc.Metadata.DocType
+	country := doc.Metadata.Country
+	customerID := doc.Metadata.CustomerID
+	content, _ := doc.Spec["content"].(map[string]interface{})
+	desc := stringPtrField(doc.Spec, "description")
+
+	manifestHash, err := manifestContentHash(content)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: failed to hash manifest content: %w", doc.Source, err)
+	}
+	storedDescription := withManifestHash(desc, manifestHash)
+
+	after := map[string]interface{}{"content": content}
+
+	if existing == nil {
+		change := Change{
+			Kind: manifest.KindSchema, Code: name, Action: ActionCreate, After: after,
+			apply: func(ctx context.Context, c *client.Client) error {
+				created, err := c.CreateSchema(ctx, &client.CreateSchemaRequest{
+					Name:        name,
+					DocTypeCode: docType,
+					CountryCode: optionalString(country),
+					Content:     content,
+					Description: storedDescription,
+					CustomerID:  optionalString(customerID),
+				})
+				if err != nil {
+					return err
+				}
+				if targetStatus == "" {
+					return nil
+				}
+				return transitionToStatus(ctx, c, created.PublicID, created.PublicVersionID, created.Status, client.Status(targetStatus))
+			},
+		}
+		return []Change{change}, "", nil
+	}
+
+	publicID := existing.PublicID
+	_, baseHash := splitManifestHash(existing.Description)
+	liveHash, err := manifestContentHash(existing.Content)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: failed to hash live content for %s: %w", doc.Source, publicID, err)
+	}
+	if baseHash != "" && liveHash != baseHash {
+		return nil, "", fmt.Errorf("%s: schema %s has drifted since the last apply (its live content no longer matches the recorded manifest hash) — resolve the drift manually before re-applying", doc.Source, publicID)
+	}
+
+	before := map[string]interface{}{"content": existing.Content}
+	var changes []Change
+	switch {
+	case !equalJSON(before, after):
+		changes = append(changes, Change{
+			Kind: manifest.KindSchema, Code: publicID, Action: ActionUpdate, Before: before, After: after,
+			apply: func(ctx context.Context, c *client.Client) error {
+				_, err := c.UpdateSchema(ctx, publicID, &client.UpdateSchemaRequest{Content: content, Description: storedDescription})
+				return err
+			},
+		})
+	case ptrVal(existing.Description) != ptrVal(storedDescription):
+		// Content already matches, but the recorded manifest hash needs to
+		// catch up — e.g. this schema has never been applied before.
+		changes = append(changes, Change{
+			Kind: manifest.KindSchema, Code: publicID, Action: ActionUpdate, Before: before, After: after,
+			apply: func(ctx context.Context, c *client.Client) error {
+				_, err := c.UpdateSchema(ctx, publicID, &client.UpdateSchemaRequest{Description: storedDescription})
+				return err
+			},
+		})
+	default:
+		changes = append(changes, Change{Kind: manifest.KindSchema, Code: publicID, Action: ActionNoOp, Before: before, After: after})
+	}
+
+	if targetStatus != "" && client.Status(targetStatus) != existing.Status {
+		from, to := existing.Status, client.Status(targetStatus)
+		pubID, pubVersionID := existing.PublicID, existing.PublicVersionID
+		changes = append(changes, Change{
+			Kind: manifest.KindSchema, Code: publicID, Action: ActionTransition,
+			Before: map[string]interface{}{"status": from}, After: map[string]interface{}{"status": to},
+			apply: func(ctx context.Context, c *client.Client) error {
+				return transitionToStatus(ctx, c, pubID, pubVersionID, from, to)
+			},
+		})
+	}
+
+	return changes, publicID, nil
+}
+
+// findSchema matches doc against an existing registry schema: by an
+// explicit metadata.code (publicId) override when set, otherwise by
+// name+docType+country+customerId. It returns a nil *SchemaWithRelations
+// (not an error) when no match is found by the search path, so the caller
+// can create it — but a *set* metadata.code that doesn't resolve is always
+// an error, since apply can't create a schema under a caller-chosen id.
+func findSchema(ctx context.Context, c *client.Client, doc manifest.Document) (*client.SchemaWithRelations, error) {
+	if doc.Metadata.Code != "" {
+		existing, err := c.GetSchema(ctx, doc.Metadata.Code)
+		if err != nil {
+			return nil, fmt.Errorf("%s: metadata.code %q (explicit publicId) not found: %w", doc.Source, doc.Metadata.Code, err)
+		}
+		return existing, nil
+	}
+
+	docType := doc.Metadata.DocType
+	country := doc.Metadata.Country
+	customerID := doc.Metadata.CustomerID
+	opts := &client.ListSchemasOptions{DocType: &docType, Limit: 100}
+	if country != "" {
+		opts.Country = &country
+	}
+	if customerID != "" {
+		opts.CustomerID = &customerID
+	}
+	page, err := c.ListSchemas(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to search for schema %q: %w", doc.Source, doc.Metadata.Name, err)
+	}
+	for _, s := range page.Data {
+		if s.Name == doc.Metadata.Name {
+			found := s
+			return &found, nil
+		}
+	}
+	return nil, nil
+}
+
+// parseCustomerSelector parses --selector for schema pruning, the only
+// selector form schemas apply supports.
+func parseCustomerSelector(selector string) (string, error) {
+	const prefix = "customer-id="
+	if !strings.HasPrefix(selector, prefix) {
+		return "", fmt.Errorf(`--prune requires --selector "customer-id=<value>" when applying schemas, got %q`, selector)
+	}
+	value := strings.TrimPrefix(selector, prefix)
+	if value == "" {
+		return "", fmt.Errorf("--selector customer-id=<value> requires a non-empty value")
+	}
+	return value, nil
+}
+
+// planSchemaPrune deprecates (not deletes — a schema may already have data
+// classified against it) every schema owned by customerID that wasn't
+// matched by the manifest set just applied.
+func planSchemaPrune(ctx context.Context, c *client.Client, managed map[string]bool, customerID string) ([]Change, error) {
+	opts := &client.ListSchemasOptions{CustomerID: &customerID, Limit: 100}
+	page, err := c.ListSchemas(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list schemas for pruning: %w", err)
+	}
+
+	var changes []Change
+	for _, s := range page.Data {
+		if managed[s.PublicID] {
+			continue
+		}
+		publicID, status := s.PublicID, s.Status
+		changes = append(changes, Change{
+			Kind: manifest.KindSchema, Code: publicID, Action: ActionPrune,
+			Before: map[string]interface{}{"status": status},
+			apply: func(ctx context.Context, c *client.Client) error {
+				if status == client.StatusDeprecated {
+					return nil
+				}
+				_, err := c.DeprecateSchema(ctx, publicID)
+				return err
+			},
+		})
+	}
+	return changes, nil
+}
+
+// transitionToStatus drives a schema from "from" to "to" via the lifecycle
+// actions ActivateSchema/DeprecateSchema expose directly, falling back to
+// PerformAction(recover) for deprecated->active (neither convenience method
+// covers it). There is no legal transition back to draft once a schema has
+// left it (see client/lifecycle.go's transitions table), so
+// targetStatus: draft against a non-draft schema is reported as an explicit
+// error rather than silently ignored.
+func transitionToStatus(ctx context.Context, c *client.Client, publicID, publicVersionID string, from, to client.Status) error {
+	if from == to {
+		return nil
+	}
+	switch to {
+	case client.StatusActive:
+		if from == client.StatusDeprecated {
+			_, err := c.PerformAction(ctx, publicVersionID, client.ActionRequest{Action: client.ActionRecover, Message: "schemas apply"})
+			return err
+		}
+		_, err := c.ActivateSchema(ctx, publicID)
+		return err
+	case client.StatusDeprecated:
+		_, err := c.DeprecateSchema(ctx, publicID)
+		return err
+	case client.StatusDraft:
+		return fmt.Errorf("targetStatus \"draft\" is not reachable from status %q: the registry has no transition back to draft once a schema has left it", from)
+	default:
+		return fmt.Errorf("unsupported targetStatus %q", to)
+	}
+}
+
+func manifestContentHash(content map[string]interface{}) (string, error) {
+	data, err := json.Marshal(content)
+	if err != nil {
+		return "", err
+	}
+	return client.CanonicalizeAndHash(data)
+}
+
+// withManifestHash appends hash to the manifest's own description text (if
+// any) in the format splitManifestHash expects back.
+func withManifestHash(desc *string, hash string) *string {
+	full := ptrVal(desc) + manifestHashMarker + hash
+	return &full
+}
+
+// splitManifestHash separates a stored Description back into its free-text
+// base and the last-applied-manifest hash, if present.
+func splitManifestHash(desc *string) (base, hash string) {
+	if desc == nil {
+		return "", ""
+	}
+	idx := strings.Index(*desc, manifestHashMarker)
+	if idx < 0 {
+		return *desc, ""
+	}
+	return (*desc)[:idx], (*desc)[idx+len(manifestHashMarker):]
+}
@@ -0,0 +1,180 @@
+// Package archive implements `schemactl export`/`import`: a full registry
+// backup/restore as a single tar+gzip archive containing manifest.json,
+// doc-types.json, countries.json, and one schemas/<publicId>.json per
+// schema, importable with atomic all-or-nothing rollback. It's a different
+// artifact from internal/bundle's directory-tree format (schema-only,
+// scoped to promoting a subset of schemas between environments) and from
+// internal/bulk's directory-tree format (the whole registry, but meant to
+// be checked into git and reconciled incrementally rather than restored as
+// one atomic unit): archive is for point-in-time whole-registry backup and
+// disaster recovery, where you want one file and no partial state.
+package archive
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/bundle"
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/client"
+)
+
+// ManifestVersion is the archive format version written to manifest.json.
+const ManifestVersion = 1
+
+// Manifest summarizes an archive's contents.
+type Manifest struct {
+	Version    int       `json:"version"`
+	ExportedAt time.Time `json:"exportedAt"`
+	Filter     string    `json:"filter,omitempty"`
+	DocTypes   int       `json:"docTypes"`
+	Countries  int       `json:"countries"`
+	Schemas    int       `json:"schemas"`
+}
+
+// ExportOptions configures an export run.
+type ExportOptions struct {
+	Filter     bundle.Filter
+	FilterExpr string
+
+	// Progress, if set, receives a human-readable line for each resource as
+	// it's exported, meant to be written to stderr.
+	Progress func(msg string)
+}
+
+// Export writes a tar+gzip archive of every doc type, country, and
+// (filtered) schema the caller can see to w.
+func Export(ctx context.Context, c *client.Client, w io.Writer, opts ExportOptions) (Manifest, error) {
+	progress := opts.Progress
+	if progress == nil {
+		progress = func(string) {}
+	}
+
+	manifest := Manifest{Version: ManifestVersion, ExportedAt: time.Now(), Filter: opts.FilterExpr}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	docTypes, err := c.ListDocTypes(ctx, true)
+	if err != nil {
+		return manifest, fmt.Errorf("failed to list doc types: %w", err)
+	}
+	progress(fmt.Sprintf("exporting %d doc type(s)", len(docTypes)))
+	if err := writeJSONEntry(tw, "doc-types.json", docTypes); err != nil {
+		return manifest, err
+	}
+	manifest.DocTypes = len(docTypes)
+
+	countries, err := c.ListCountries(ctx, true)
+	if err != nil {
+		return manifest, fmt.Errorf("failed to list countries: %w", err)
+	}
+	progress(fmt.Sprintf("exporting %d countr(y/ies)", len(countries)))
+	if err := writeJSONEntry(tw, "countries.json", countries); err != nil {
+		return manifest, err
+	}
+	manifest.Countries = len(countries)
+
+	const pageSize = 100
+	offset := 0
+	for {
+		page, err := c.ListSchemas(ctx, &client.ListSchemasOptions{Limit: pageSize, Offset: offset})
+		if err != nil {
+			return manifest, fmt.Errorf("failed to list schemas: %w", err)
+		}
+
+		for _, s := range page.Data {
+			country := ""
+			if s.CountryCode != nil {
+				country = *s.CountryCode
+			}
+			if !opts.Filter.Matches(s.DocTypeCode, country) {
+				continue
+			}
+
+			full, err := c.GetSchema(ctx, s.PublicVersionID)
+			if err != nil {
+				return manifest, fmt.Errorf("failed to fetch %s: %w", s.PublicVersionID, err)
+			}
+
+			progress(fmt.Sprintf("exporting schema %s (%s)", full.PublicID, full.Name))
+			if err := writeJSONEntry(tw, fmt.Sprintf("schemas/%s.json", full.PublicID), full); err != nil {
+				return manifest, err
+			}
+			manifest.Schemas++
+		}
+
+		if !page.Pagination.HasMore {
+			break
+		}
+		offset += pageSize
+	}
+
+	if err := writeJSONEntry(tw, "manifest.json", manifest); err != nil {
+		return manifest, err
+	}
+
+	if err := tw.Close(); err != nil {
+		return manifest, fmt.Errorf("failed to finalize archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return manifest, fmt.Errorf("failed to finalize archive: %w", err)
+	}
+	return manifest, nil
+}
+
+func writeJSONEntry(tw *tar.Writer, name string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", name, err)
+	}
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0o644,
+		Size:    int64(len(data)),
+		ModTime: time.Now(),
+	}); err != nil {
+		return fmt.Errorf("failed to write %s header: %w", name, err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+// readArchive slurps a tar+gzip archive into memory, keyed by entry name.
+// Archives produced by Export are small enough (JSON reference data plus
+// per-schema documents) that buffering them is simpler than streaming, and
+// it lets Import read manifest.json up front regardless of entry order.
+func readArchive(r io.Reader) (map[string][]byte, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer gz.Close()
+
+	entries := map[string][]byte{}
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", header.Name, err)
+		}
+		entries[header.Name] = data
+	}
+	return entries, nil
+}
@@ -0,0 +1,349 @@
+package archive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/client"
+)
+
+// OnConflict selects how Import reconciles a resource that already exists
+// on the target server.
+type OnConflict string
+
+const (
+	// OnConflictSkip leaves the existing resource untouched.
+	OnConflictSkip OnConflict = "skip"
+	// OnConflictOverwrite replaces the existing resource in place, losing
+	// any prior schema version history.
+	OnConflictOverwrite OnConflict = "overwrite"
+	// OnConflictVersion updates the existing schema through the registry's
+	// normal versioning path (a no-op distinction for doc types/countries,
+	// which have no version history).
+	OnConflictVersion OnConflict = "version"
+)
+
+// ParseOnConflict parses the --on-conflict flag value.
+func ParseOnConflict(s string) (OnConflict, error) {
+	switch OnConflict(strings.ToLower(s)) {
+	case OnConflictSkip, OnConflictOverwrite, OnConflictVersion:
+		return OnConflict(strings.ToLower(s)), nil
+	default:
+		return "", fmt.Errorf("invalid --on-conflict value %q (expected skip, overwrite, or version)", s)
+	}
+}
+
+// ImportOptions configures an import run.
+type ImportOptions struct {
+	DryRun     bool
+	OnConflict OnConflict
+
+	// Progress, if set, receives a human-readable line for each resource as
+	// it's reconciled, meant to be written to stderr.
+	Progress func(msg string)
+}
+
+// Diff describes what Import did (or, in dry-run mode, would do) to a
+// single resource.
+type Diff struct {
+	Kind   string `json:"kind"` // "docType", "country", or "schema"
+	Code   string `json:"code"`
+	Action string `json:"action"` // "create", "update", "version", "skip", "unchanged"
+}
+
+// ImportResult summarizes an import run.
+type ImportResult struct {
+	Manifest   Manifest `json:"manifest"`
+	Diffs      []Diff   `json:"diffs"`
+	Created    int      `json:"created"`
+	Updated    int      `json:"updated"`
+	Skipped    int      `json:"skipped"`
+	RolledBack bool     `json:"rolledBack"`
+}
+
+// rollbackAction undoes a single change Import already applied, used to
+// approximate a transaction against a remote API that has none: if any
+// later resource fails, every rollbackAction recorded so far runs in
+// reverse order.
+type rollbackAction func(ctx context.Context, c *client.Client) error
+
+// Import reads a tar+gzip archive produced by Export and reconciles its
+// doc types, countries, and schemas against the target server. If any
+// resource fails partway through, every change already applied is rolled
+// back in reverse order so the server is left as it was found.
+func Import(ctx context.Context, c *client.Client, r io.Reader, opts ImportOptions) (ImportResult, error) {
+	progress := opts.Progress
+	if progress == nil {
+		progress = func(string) {}
+	}
+
+	var result ImportResult
+
+	entries, err := readArchive(r)
+	if err != nil {
+		return result, err
+	}
+
+	if data, ok := entries["manifest.json"]; ok {
+		if err := json.Unmarshal(data, &result.Manifest); err != nil {
+			return result, fmt.Errorf("failed to parse manifest.json: %w", err)
+		}
+	}
+
+	var docTypes []client.DocType
+	if data, ok := entries["doc-types.json"]; ok {
+		if err := json.Unmarshal(data, &docTypes); err != nil {
+			return result, fmt.Errorf("failed to parse doc-types.json: %w", err)
+		}
+	}
+
+	var countries []client.Country
+	if data, ok := entries["countries.json"]; ok {
+		if err := json.Unmarshal(data, &countries); err != nil {
+			return result, fmt.Errorf("failed to parse countries.json: %w", err)
+		}
+	}
+
+	var schemas []client.SchemaWithRelations
+	for name, data := range entries {
+		if !strings.HasPrefix(name, "schemas/") {
+			continue
+		}
+		var s client.SchemaWithRelations
+		if err := json.Unmarshal(data, &s); err != nil {
+			return result, fmt.Errorf("failed to parse %s: %w", name, err)
+		}
+		schemas = append(schemas, s)
+	}
+
+	var rollbacks []rollbackAction
+	fail := func(err error) (ImportResult, error) {
+		if !opts.DryRun {
+			for i := len(rollbacks) - 1; i >= 0; i-- {
+				if rbErr := rollbacks[i](ctx, c); rbErr != nil {
+					progress(fmt.Sprintf("rollback step failed: %v", rbErr))
+				}
+			}
+			result.RolledBack = len(rollbacks) > 0
+		}
+		return result, err
+	}
+
+	for _, dt := range docTypes {
+		diff, rb, err := importDocType(ctx, c, dt, opts)
+		if err != nil {
+			return fail(fmt.Errorf("doc type %s: %w", dt.Code, err))
+		}
+		progress(fmt.Sprintf("doc type %s: %s", dt.Code, diff.Action))
+		result.Diffs = append(result.Diffs, diff)
+		tally(&result, diff)
+		if rb != nil {
+			rollbacks = append(rollbacks, rb)
+		}
+	}
+
+	for _, country := range countries {
+		diff, rb, err := importCountry(ctx, c, country, opts)
+		if err != nil {
+			return fail(fmt.Errorf("country %s: %w", country.Code, err))
+		}
+		progress(fmt.Sprintf("country %s: %s", country.Code, diff.Action))
+		result.Diffs = append(result.Diffs, diff)
+		tally(&result, diff)
+		if rb != nil {
+			rollbacks = append(rollbacks, rb)
+		}
+	}
+
+	for _, s := range schemas {
+		diff, rb, err := importSchema(ctx, c, s, opts)
+		if err != nil {
+			return fail(fmt.Errorf("schema %s: %w", s.Name, err))
+		}
+		progress(fmt.Sprintf("schema %s: %s", s.Name, diff.Action))
+		result.Diffs = append(result.Diffs, diff)
+		tally(&result, diff)
+		if rb != nil {
+			rollbacks = append(rollbacks, rb)
+		}
+	}
+
+	return result, nil
+}
+
+func tally(result *ImportResult, diff Diff) {
+	switch diff.Action {
+	case "create":
+		result.Created++
+	case "update", "version":
+		result.Updated++
+	case "skip", "unchanged":
+		result.Skipped++
+	}
+}
+
+func importDocType(ctx context.Context, c *client.Client, dt client.DocType, opts ImportOptions) (Diff, rollbackAction, error) {
+	existing, err := c.GetDocType(ctx, dt.Code)
+	if err != nil {
+		if !client.IsNotFound(err) {
+			return Diff{}, nil, fmt.Errorf("failed to look up doc type %q: %w", dt.Code, err)
+		}
+		diff := Diff{Kind: "docType", Code: dt.Code, Action: "create"}
+		if opts.DryRun {
+			return diff, nil, nil
+		}
+		if _, err := c.CreateDocType(ctx, &client.CreateDocTypeRequest{Code: dt.Code, Name: dt.Name, Description: dt.Description}); err != nil {
+			return diff, nil, fmt.Errorf("failed to create: %w", err)
+		}
+		code := dt.Code
+		return diff, func(ctx context.Context, c *client.Client) error { return c.DeleteDocType(ctx, code) }, nil
+	}
+
+	if opts.OnConflict == OnConflictSkip {
+		return Diff{Kind: "docType", Code: dt.Code, Action: "skip"}, nil, nil
+	}
+
+	diff := Diff{Kind: "docType", Code: dt.Code, Action: "update"}
+	if opts.DryRun {
+		return diff, nil, nil
+	}
+	if _, err := c.UpdateDocType(ctx, dt.Code, &client.UpdateDocTypeRequest{Name: &dt.Name, Description: dt.Description, IsActive: &dt.IsActive}); err != nil {
+		return diff, nil, fmt.Errorf("failed to update: %w", err)
+	}
+	prev := *existing
+	return diff, func(ctx context.Context, c *client.Client) error {
+		_, err := c.UpdateDocType(ctx, prev.Code, &client.UpdateDocTypeRequest{Name: &prev.Name, Description: prev.Description, IsActive: &prev.IsActive})
+		return err
+	}, nil
+}
+
+func importCountry(ctx context.Context, c *client.Client, country client.Country, opts ImportOptions) (Diff, rollbackAction, error) {
+	existing, err := c.GetCountry(ctx, country.Code)
+	if err != nil {
+		if !client.IsNotFound(err) {
+			return Diff{}, nil, fmt.Errorf("failed to look up country %q: %w", country.Code, err)
+		}
+		diff := Diff{Kind: "country", Code: country.Code, Action: "create"}
+		if opts.DryRun {
+			return diff, nil, nil
+		}
+		if _, err := c.CreateCountry(ctx, &client.CreateCountryRequest{Code: country.Code, Name: country.Name}); err != nil {
+			return diff, nil, fmt.Errorf("failed to create: %w", err)
+		}
+		code := country.Code
+		return diff, func(ctx context.Context, c *client.Client) error { return c.DeleteCountry(ctx, code) }, nil
+	}
+
+	if opts.OnConflict == OnConflictSkip {
+		return Diff{Kind: "country", Code: country.Code, Action: "skip"}, nil, nil
+	}
+
+	diff := Diff{Kind: "country", Code: country.Code, Action: "update"}
+	if opts.DryRun {
+		return diff, nil, nil
+	}
+	if _, err := c.UpdateCountry(ctx, country.Code, &client.UpdateCountryRequest{Name: &country.Name, IsActive: &country.IsActive}); err != nil {
+		return diff, nil, fmt.Errorf("failed to update: %w", err)
+	}
+	prev := *existing
+	return diff, func(ctx context.Context, c *client.Client) error {
+		_, err := c.UpdateCountry(ctx, prev.Code, &client.UpdateCountryRequest{Name: &prev.Name, IsActive: &prev.IsActive})
+		return err
+	}, nil
+}
+
+// importSchema reconciles one schema by the same identity an apply-style
+// reconciliation uses: name + doc type + country.
+func importSchema(ctx context.Context, c *client.Client, s client.SchemaWithRelations, opts ImportOptions) (Diff, rollbackAction, error) {
+	existing, err := findExistingSchema(ctx, c, s)
+	if err != nil {
+		return Diff{}, nil, err
+	}
+
+	if existing == nil {
+		diff := Diff{Kind: "schema", Code: s.PublicID, Action: "create"}
+		if opts.DryRun {
+			return diff, nil, nil
+		}
+		created, err := c.CreateSchema(ctx, toCreateSchemaRequest(s))
+		if err != nil {
+			return diff, nil, fmt.Errorf("failed to create: %w", err)
+		}
+		publicID := created.PublicID
+		return diff, func(ctx context.Context, c *client.Client) error { return c.DeleteSchema(ctx, publicID) }, nil
+	}
+
+	if opts.OnConflict == OnConflictSkip {
+		return Diff{Kind: "schema", Code: existing.PublicID, Action: "skip"}, nil, nil
+	}
+
+	prev := *existing
+	if opts.OnConflict == OnConflictOverwrite {
+		diff := Diff{Kind: "schema", Code: existing.PublicID, Action: "update"}
+		if opts.DryRun {
+			return diff, nil, nil
+		}
+		if err := c.DeleteSchema(ctx, existing.PublicID); err != nil {
+			return diff, nil, fmt.Errorf("failed to delete for overwrite: %w", err)
+		}
+		created, err := c.CreateSchema(ctx, toCreateSchemaRequest(s))
+		if err != nil {
+			return diff, nil, fmt.Errorf("failed to recreate: %w", err)
+		}
+		newID := created.PublicID
+		return diff, func(ctx context.Context, c *client.Client) error {
+			if err := c.DeleteSchema(ctx, newID); err != nil {
+				return err
+			}
+			_, err := c.CreateSchema(ctx, toCreateSchemaRequest(prev))
+			return err
+		}, nil
+	}
+
+	// OnConflictVersion: update through the registry's normal versioning path.
+	diff := Diff{Kind: "schema", Code: existing.PublicID, Action: "version"}
+	if opts.DryRun {
+		return diff, nil, nil
+	}
+	if _, err := c.UpdateSchema(ctx, existing.PublicID, &client.UpdateSchemaRequest{Content: s.Content}); err != nil {
+		return diff, nil, fmt.Errorf("failed to update: %w", err)
+	}
+	return diff, func(ctx context.Context, c *client.Client) error {
+		_, err := c.UpdateSchema(ctx, prev.PublicID, &client.UpdateSchemaRequest{Content: prev.Content})
+		return err
+	}, nil
+}
+
+func findExistingSchema(ctx context.Context, c *client.Client, s client.SchemaWithRelations) (*client.SchemaWithRelations, error) {
+	opts := &client.ListSchemasOptions{DocType: &s.DocTypeCode, Limit: 100}
+	if s.CountryCode != nil {
+		opts.Country = s.CountryCode
+	}
+	page, err := c.ListSchemas(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search for existing schema: %w", err)
+	}
+	for _, candidate := range page.Data {
+		if candidate.Name == s.Name {
+			found := candidate
+			return &found, nil
+		}
+	}
+	return nil, nil
+}
+
+func toCreateSchemaRequest(s client.SchemaWithRelations) *client.CreateSchemaRequest {
+	return &client.CreateSchemaRequest{
+		Name:        s.Name,
+		Description: s.Description,
+		Content:     s.Content,
+		DocTypeCode: s.DocTypeCode,
+		CountryCode: s.CountryCode,
+		Visibility:  &s.Visibility,
+		SchemaType:  &s.SchemaType,
+		CustomerID:  s.CustomerID,
+	}
+}
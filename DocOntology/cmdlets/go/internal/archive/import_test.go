@@ -0,0 +1,80 @@
+package archive
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/client"
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/config"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *client.Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	c, err := client.New(&config.Config{APIBaseURL: srv.URL, Timeout: 5}, client.WithMaxRetries(0))
+	if err != nil {
+		t.Fatalf("client.New: %v", err)
+	}
+	return c
+}
+
+func TestImportDocTypeMissingCreates(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(client.ErrorResponse{Error: "not found"})
+	})
+
+	diff, _, err := importDocType(context.Background(), c, client.DocType{Code: "INV", Name: "Invoice"}, ImportOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("importDocType: unexpected error: %v", err)
+	}
+	if diff.Action != "create" {
+		t.Errorf("got action %q, want %q", diff.Action, "create")
+	}
+}
+
+// TestImportDocTypeServerErrorPropagates guards against a 5xx GetDocType
+// failure being mistaken for "doesn't exist yet" and attempted as a create.
+func TestImportDocTypeServerErrorPropagates(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(client.ErrorResponse{Error: "boom"})
+	})
+
+	_, _, err := importDocType(context.Background(), c, client.DocType{Code: "INV", Name: "Invoice"}, ImportOptions{DryRun: true})
+	if err == nil {
+		t.Fatal("importDocType: expected an error from a 500 response, got nil")
+	}
+}
+
+func TestImportCountryMissingCreates(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(client.ErrorResponse{Error: "not found"})
+	})
+
+	diff, _, err := importCountry(context.Background(), c, client.Country{Code: "PT", Name: "Portugal"}, ImportOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("importCountry: unexpected error: %v", err)
+	}
+	if diff.Action != "create" {
+		t.Errorf("got action %q, want %q", diff.Action, "create")
+	}
+}
+
+func TestImportCountryServerErrorPropagates(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(client.ErrorResponse{Error: "boom"})
+	})
+
+	_, _, err := importCountry(context.Background(), c, client.Country{Code: "PT", Name: "Portugal"}, ImportOptions{DryRun: true})
+	if err == nil {
+		t.Fatal("importCountry: expected an error from a 500 response, got nil")
+	}
+}
@@ -0,0 +1,122 @@
+// Package batchmatch drives `schemas match` over many files concurrently: a
+// bounded worker pool classifies each file against the registry, retrying
+// transient (5xx) failures with exponential backoff.
+package batchmatch
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/client"
+)
+
+// Result is one file's classification outcome, successful or not.
+type Result struct {
+	File      string
+	DocType   string
+	Country   string
+	MatchType string
+	SchemaID  string
+	VersionID string
+	Duration  time.Duration
+	Err       string
+}
+
+// Options configures a batch run.
+type Options struct {
+	Parallel   int
+	CustomerID *string
+	MaxRetries int
+}
+
+// Run classifies every file in files, distributing work across Parallel
+// workers, and returns one Result per file in the order tasks complete. A
+// cancelled ctx is reflected in each in-flight file's Result.Err rather than
+// aborting the whole batch early, so callers still get a result per file.
+func Run(ctx context.Context, c *client.Client, files []string, opts Options) []Result {
+	if opts.Parallel < 1 {
+		opts.Parallel = 1
+	}
+
+	jobs := make(chan string)
+	results := make(chan Result)
+
+	for w := 0; w < opts.Parallel; w++ {
+		go func() {
+			for file := range jobs {
+				results <- matchOne(ctx, c, file, opts)
+			}
+		}()
+	}
+
+	go func() {
+		for _, f := range files {
+			jobs <- f
+		}
+		close(jobs)
+	}()
+
+	out := make([]Result, len(files))
+	for i := range files {
+		out[i] = <-results
+	}
+	return out
+}
+
+func matchOne(ctx context.Context, c *client.Client, file string, opts Options) Result {
+	start := time.Now()
+	result, err := matchWithRetry(ctx, c, file, opts)
+	duration := time.Since(start)
+
+	if err != nil {
+		return Result{File: file, Duration: duration, Err: err.Error()}
+	}
+
+	r := Result{
+		File:     file,
+		Duration: duration,
+		DocType:  result.Classification.DocType,
+		Country:  result.Classification.Country,
+	}
+	if result.Schema != nil {
+		r.SchemaID = result.Schema.PublicID
+		r.VersionID = result.Schema.PublicVersionID
+		r.MatchType = string(result.Schema.SchemaType)
+	}
+	return r
+}
+
+// matchWithRetry retries a match up to opts.MaxRetries times, backing off
+// exponentially (with jitter) between attempts, but only for retryable
+// (5xx) API errors — a 4xx or I/O failure fails fast.
+func matchWithRetry(ctx context.Context, c *client.Client, file string, opts Options) (*client.ExtractResponse, error) {
+	var lastErr error
+
+	attempts := opts.MaxRetries + 1
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	for attempt := 0; attempt < attempts; attempt++ {
+		// No progress callback: files run concurrently across the worker
+		// pool, so a single terminal line can't meaningfully track them all.
+		result, err := c.MatchSchema(ctx, file, opts.CustomerID, nil)
+		if err == nil {
+			return result, nil
+		}
+		lastErr = err
+
+		var apiErr *client.APIError
+		if !errors.As(err, &apiErr) || !apiErr.Retryable() || attempt == attempts-1 {
+			return nil, err
+		}
+
+		backoff := time.Duration(1<<attempt) * 200 * time.Millisecond
+		backoff += time.Duration(rand.Intn(100)) * time.Millisecond
+		time.Sleep(backoff)
+	}
+
+	return nil, lastErr
+}
@@ -0,0 +1,33 @@
+// Package bulk round-trips the entire registry (doc types, countries, and
+// schemas with their full version history) as a git-friendly tree of YAML
+// files, so a fleet of environments can be seeded, audited, and reconciled
+// from a single checked-in directory.
+//
+// It differs from internal/apply (a single flat manifest stream) and
+// internal/bundle (schema-only, JSON, no dependency ordering) in three ways:
+// resources are topologically ordered so doc types and countries are always
+// created before the schemas that reference them, changes are diffed by
+// content summary rather than raw field comparison, and export captures a
+// schema's full version history rather than just its latest version.
+//
+// It differs from internal/archive (also whole-registry) in shape and
+// intent: archive is a single tar+gzip artifact meant to be restored
+// atomically for backup/disaster-recovery, while bulk is a plain directory
+// tree of individually-diffable YAML files meant to be checked into git and
+// reconciled incrementally, the way `apply` reconciles a manifest — use
+// archive to snapshot/restore the whole registry, bulk to manage it as
+// code.
+//
+// client.Client gains no new HTTP methods for this package: applying a
+// schema resource only ever creates or updates its latest version, since the
+// registry API has no endpoint to bulk-replay an arbitrary version history.
+package bulk
+
+// Options configures how a Plan is built. Dry-run is not a Plan concern:
+// building a Plan never mutates the server, so callers that want a dry run
+// simply print the Plan and skip calling Execute (see `schemactl apply`).
+type Options struct {
+	// Prune deletes server-side doc types, countries, and schemas absent
+	// from the loaded Tree.
+	Prune bool
+}
@@ -0,0 +1,51 @@
+package bulk
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/client"
+)
+
+// Result summarizes a completed Execute.
+type Result struct {
+	Created int
+	Updated int
+	Pruned  int
+}
+
+// Executor applies Plans against a registry. Like Planner, it holds no
+// state of its own; it exists as a type so Plan and Execute can be driven
+// and tested independently of each other.
+type Executor struct {
+	client *client.Client
+}
+
+// NewExecutor returns an Executor that applies Plans against c.
+func NewExecutor(c *client.Client) *Executor {
+	return &Executor{client: c}
+}
+
+// Execute applies every non-no-op change in plan, in order, stopping at the
+// first error so a partially-applied plan is always resumable by re-running
+// Plan and Execute from scratch.
+func (e *Executor) Execute(ctx context.Context, plan Plan) (Result, error) {
+	var result Result
+	for _, change := range plan.Changes {
+		if change.Action == ActionNoOp || change.apply == nil {
+			continue
+		}
+		if err := change.apply(ctx, e.client); err != nil {
+			return result, fmt.Errorf("%s %s %q: %w", change.Action, change.Kind, change.Code, err)
+		}
+		switch change.Action {
+		case ActionCreate:
+			result.Created++
+		case ActionUpdate:
+			result.Updated++
+		case ActionPrune:
+			result.Pruned++
+		}
+	}
+	return result, nil
+}
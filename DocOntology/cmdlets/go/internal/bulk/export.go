@@ -0,0 +1,141 @@
+package bulk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/client"
+	"gopkg.in/yaml.v3"
+)
+
+// ExportResult summarizes a completed Export.
+type ExportResult struct {
+	DocTypes  int
+	Countries int
+	Schemas   int
+}
+
+// Export writes every doc type, country, and schema (with its full version
+// history) the caller can see to outDir, in the layout LoadTree reads back:
+// doc-types/<code>.yaml, countries/<code>.yaml, and
+// schemas/<docType>/<country>/<publicId>.yaml.
+func Export(ctx context.Context, c *client.Client, outDir string) (ExportResult, error) {
+	var result ExportResult
+
+	docTypes, err := c.ListDocTypes(ctx, true)
+	if err != nil {
+		return result, fmt.Errorf("failed to list doc types: %w", err)
+	}
+	for _, dt := range docTypes {
+		res := DocTypeResource{Code: dt.Code, Name: dt.Name, Description: dt.Description, IsActive: dt.IsActive}
+		if err := writeYAML(docTypePath(outDir, dt.Code), res); err != nil {
+			return result, err
+		}
+		result.DocTypes++
+	}
+
+	countries, err := c.ListCountries(ctx, true)
+	if err != nil {
+		return result, fmt.Errorf("failed to list countries: %w", err)
+	}
+	for _, country := range countries {
+		res := CountryResource{Code: country.Code, Name: country.Name, IsActive: country.IsActive}
+		if err := writeYAML(countryPath(outDir, country.Code), res); err != nil {
+			return result, err
+		}
+		result.Countries++
+	}
+
+	const pageSize = 100
+	offset := 0
+	for {
+		page, err := c.ListSchemas(ctx, &client.ListSchemasOptions{Limit: pageSize, Offset: offset})
+		if err != nil {
+			return result, fmt.Errorf("failed to list schemas: %w", err)
+		}
+
+		for _, s := range page.Data {
+			res, err := exportSchema(ctx, c, s.PublicID)
+			if err != nil {
+				return result, err
+			}
+			if err := writeYAML(schemaPath(outDir, res), res); err != nil {
+				return result, err
+			}
+			result.Schemas++
+		}
+
+		if !page.Pagination.HasMore {
+			break
+		}
+		offset += pageSize
+	}
+
+	return result, nil
+}
+
+// exportSchema fetches every version of the schema identified by publicID
+// and assembles it into a SchemaResource, oldest version first.
+func exportSchema(ctx context.Context, c *client.Client, publicID string) (SchemaResource, error) {
+	versions, err := c.GetSchemaVersions(ctx, publicID)
+	if err != nil {
+		return SchemaResource{}, fmt.Errorf("failed to fetch versions for %s: %w", publicID, err)
+	}
+
+	sort.Slice(versions, func(i, j int) bool { return versions[i].Version < versions[j].Version })
+
+	latest := versions[len(versions)-1]
+	res := SchemaResource{
+		PublicID:    latest.PublicID,
+		Name:        latest.Name,
+		Description: latest.Description,
+		DocTypeCode: latest.DocTypeCode,
+		Visibility:  latest.Visibility,
+		SchemaType:  latest.SchemaType,
+	}
+	if latest.CountryCode != nil {
+		res.CountryCode = *latest.CountryCode
+	}
+	if latest.CustomerID != nil {
+		res.CustomerID = *latest.CustomerID
+	}
+
+	for _, v := range versions {
+		contentJSON, err := json.Marshal(v.Content)
+		if err != nil {
+			return SchemaResource{}, fmt.Errorf("failed to marshal content for %s v%d: %w", publicID, v.Version, err)
+		}
+		summary, err := client.CanonicalizeAndHash(contentJSON)
+		if err != nil {
+			return SchemaResource{}, fmt.Errorf("failed to summarize %s v%d: %w", publicID, v.Version, err)
+		}
+		res.Versions = append(res.Versions, SchemaVersionResource{
+			Version:         v.Version,
+			PublicVersionID: v.PublicVersionID,
+			Status:          v.Status,
+			Content:         v.Content,
+			Summary:         summary,
+		})
+	}
+
+	return res, nil
+}
+
+func writeYAML(path string, v interface{}) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,333 @@
+package bulk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/client"
+)
+
+// Kind identifies which registry resource a Change describes.
+type Kind string
+
+const (
+	KindDocType Kind = "DocType"
+	KindCountry Kind = "Country"
+	KindSchema  Kind = "Schema"
+)
+
+// Action classifies what a planned change will do.
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionPrune  Action = "prune"
+	ActionNoOp   Action = "noop"
+)
+
+// Change is a single planned reconciliation step.
+type Change struct {
+	Kind   Kind
+	Code   string
+	Action Action
+	Before map[string]interface{}
+	After  map[string]interface{}
+
+	apply func(ctx context.Context, c *client.Client) error
+}
+
+// Plan is an ordered set of changes computed against the current server
+// state, with doc types and countries always ordered ahead of the schemas
+// that reference them. Building a Plan never mutates the server; pass it to
+// Executor.Execute to apply it.
+type Plan struct {
+	Changes []Change
+}
+
+// HasChanges reports whether the plan does anything beyond no-ops.
+func (p Plan) HasChanges() bool {
+	for _, c := range p.Changes {
+		if c.Action != ActionNoOp {
+			return true
+		}
+	}
+	return false
+}
+
+// Planner computes Plans against a registry. It holds no state of its own;
+// it exists as a type (rather than a free function, as internal/apply uses)
+// so Plan and Execute can be driven and tested independently.
+type Planner struct {
+	client *client.Client
+}
+
+// NewPlanner returns a Planner that queries c for current server state.
+func NewPlanner(c *client.Client) *Planner {
+	return &Planner{client: c}
+}
+
+// Plan computes the reconciliation plan for tree against the current state
+// of the registry. Doc types and countries are planned first (sorted by
+// code, for a stable diff), then schemas (already stably ordered by Export's
+// docType/country/publicId path layout) — so Executor.Execute always
+// creates a schema's doc type and country before the schema itself.
+func (p *Planner) Plan(ctx context.Context, tree Tree, opts Options) (Plan, error) {
+	var plan Plan
+
+	docTypes := append([]DocTypeResource(nil), tree.DocTypes...)
+	sort.Slice(docTypes, func(i, j int) bool { return docTypes[i].Code < docTypes[j].Code })
+	managedDocTypes := map[string]bool{}
+	for _, dt := range docTypes {
+		change, err := p.planDocType(ctx, dt)
+		if err != nil {
+			return plan, err
+		}
+		managedDocTypes[dt.Code] = true
+		plan.Changes = append(plan.Changes, change)
+	}
+
+	countries := append([]CountryResource(nil), tree.Countries...)
+	sort.Slice(countries, func(i, j int) bool { return countries[i].Code < countries[j].Code })
+	managedCountries := map[string]bool{}
+	for _, country := range countries {
+		change, err := p.planCountry(ctx, country)
+		if err != nil {
+			return plan, err
+		}
+		managedCountries[country.Code] = true
+		plan.Changes = append(plan.Changes, change)
+	}
+
+	schemas := append([]SchemaResource(nil), tree.Schemas...)
+	sort.Slice(schemas, func(i, j int) bool {
+		return schemaPath("", schemas[i]) < schemaPath("", schemas[j])
+	})
+	managedSchemas := map[string]bool{}
+	for _, s := range schemas {
+		change, err := p.planSchema(ctx, s)
+		if err != nil {
+			return plan, err
+		}
+		managedSchemas[s.PublicID] = true
+		plan.Changes = append(plan.Changes, change)
+	}
+
+	if opts.Prune {
+		pruned, err := p.planPrune(ctx, managedDocTypes, managedCountries, managedSchemas)
+		if err != nil {
+			return plan, err
+		}
+		plan.Changes = append(plan.Changes, pruned...)
+	}
+
+	return plan, nil
+}
+
+func (p *Planner) planDocType(ctx context.Context, res DocTypeResource) (Change, error) {
+	after := map[string]interface{}{"name": res.Name, "description": ptrVal(res.Description), "isActive": res.IsActive}
+
+	existing, err := p.client.GetDocType(ctx, res.Code)
+	if err != nil {
+		if !client.IsNotFound(err) {
+			return Change{}, fmt.Errorf("failed to look up doc type %q: %w", res.Code, err)
+		}
+		code := res.Code
+		return Change{
+			Kind: KindDocType, Code: code, Action: ActionCreate, After: after,
+			apply: func(ctx context.Context, c *client.Client) error {
+				_, err := c.CreateDocType(ctx, &client.CreateDocTypeRequest{Code: code, Name: res.Name, Description: res.Description})
+				return err
+			},
+		}, nil
+	}
+
+	before := map[string]interface{}{"name": existing.Name, "description": ptrVal(existing.Description), "isActive": existing.IsActive}
+	if equalJSON(before, after) {
+		return Change{Kind: KindDocType, Code: res.Code, Action: ActionNoOp, Before: before, After: after}, nil
+	}
+	code := res.Code
+	return Change{
+		Kind: KindDocType, Code: code, Action: ActionUpdate, Before: before, After: after,
+		apply: func(ctx context.Context, c *client.Client) error {
+			_, err := c.UpdateDocType(ctx, code, &client.UpdateDocTypeRequest{Name: &res.Name, Description: res.Description, IsActive: &res.IsActive})
+			return err
+		},
+	}, nil
+}
+
+func (p *Planner) planCountry(ctx context.Context, res CountryResource) (Change, error) {
+	after := map[string]interface{}{"name": res.Name, "isActive": res.IsActive}
+
+	existing, err := p.client.GetCountry(ctx, res.Code)
+	if err != nil {
+		if !client.IsNotFound(err) {
+			return Change{}, fmt.Errorf("failed to look up country %q: %w", res.Code, err)
+		}
+		code := res.Code
+		return Change{
+			Kind: KindCountry, Code: code, Action: ActionCreate, After: after,
+			apply: func(ctx context.Context, c *client.Client) error {
+				_, err := c.CreateCountry(ctx, &client.CreateCountryRequest{Code: code, Name: res.Name})
+				return err
+			},
+		}, nil
+	}
+
+	before := map[string]interface{}{"name": existing.Name, "isActive": existing.IsActive}
+	if equalJSON(before, after) {
+		return Change{Kind: KindCountry, Code: res.Code, Action: ActionNoOp, Before: before, After: after}, nil
+	}
+	code := res.Code
+	return Change{
+		Kind: KindCountry, Code: code, Action: ActionUpdate, Before: before, After: after,
+		apply: func(ctx context.Context, c *client.Client) error {
+			_, err := c.UpdateCountry(ctx, code, &client.UpdateCountryRequest{Name: &res.Name, IsActive: &res.IsActive})
+			return err
+		},
+	}, nil
+}
+
+// planSchema diffs res's latest version against the server by content
+// summary rather than a raw field comparison, so a schema re-exported and
+// re-applied unchanged is always a no-op even if map key order differs.
+func (p *Planner) planSchema(ctx context.Context, res SchemaResource) (Change, error) {
+	latest := res.Latest()
+	after := map[string]interface{}{"summary": latest.Summary}
+
+	existing, err := p.client.GetSchema(ctx, res.PublicID)
+	if err != nil {
+		if !client.IsNotFound(err) {
+			return Change{}, fmt.Errorf("failed to look up schema %q: %w", res.PublicID, err)
+		}
+		publicID := res.PublicID
+		countryCode := optionalString(res.CountryCode)
+		visibility := res.Visibility
+		schemaType := res.SchemaType
+		return Change{
+			Kind: KindSchema, Code: publicID, Action: ActionCreate, After: after,
+			apply: func(ctx context.Context, c *client.Client) error {
+				_, err := c.CreateSchema(ctx, &client.CreateSchemaRequest{
+					Name:        res.Name,
+					Description: res.Description,
+					Content:     latest.Content,
+					DocTypeCode: res.DocTypeCode,
+					CountryCode: countryCode,
+					Visibility:  &visibility,
+					SchemaType:  &schemaType,
+					CustomerID:  optionalString(res.CustomerID),
+				})
+				return err
+			},
+		}, nil
+	}
+
+	existingSummary, err := contentSummary(existing.Content)
+	if err != nil {
+		return Change{}, fmt.Errorf("failed to summarize existing content for %s: %w", res.PublicID, err)
+	}
+	before := map[string]interface{}{"summary": existingSummary}
+	if existingSummary == latest.Summary {
+		return Change{Kind: KindSchema, Code: res.PublicID, Action: ActionNoOp, Before: before, After: after}, nil
+	}
+
+	publicID := res.PublicID
+	return Change{
+		Kind: KindSchema, Code: publicID, Action: ActionUpdate, Before: before, After: after,
+		apply: func(ctx context.Context, c *client.Client) error {
+			_, err := c.UpdateSchema(ctx, publicID, &client.UpdateSchemaRequest{Content: latest.Content})
+			return err
+		},
+	}, nil
+}
+
+// planPrune finds server-side doc types, countries, and schemas absent from
+// the loaded Tree and schedules their deletion.
+func (p *Planner) planPrune(ctx context.Context, managedDocTypes, managedCountries, managedSchemas map[string]bool) ([]Change, error) {
+	var changes []Change
+
+	docTypes, err := p.client.ListDocTypes(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list doc types for pruning: %w", err)
+	}
+	for _, dt := range docTypes {
+		if managedDocTypes[dt.Code] {
+			continue
+		}
+		code := dt.Code
+		changes = append(changes, Change{
+			Kind: KindDocType, Code: code, Action: ActionPrune,
+			apply: func(ctx context.Context, c *client.Client) error { return c.DeleteDocType(ctx, code) },
+		})
+	}
+
+	countries, err := p.client.ListCountries(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list countries for pruning: %w", err)
+	}
+	for _, country := range countries {
+		if managedCountries[country.Code] {
+			continue
+		}
+		code := country.Code
+		changes = append(changes, Change{
+			Kind: KindCountry, Code: code, Action: ActionPrune,
+			apply: func(ctx context.Context, c *client.Client) error { return c.DeleteCountry(ctx, code) },
+		})
+	}
+
+	const pageSize = 100
+	offset := 0
+	for {
+		page, err := p.client.ListSchemas(ctx, &client.ListSchemasOptions{Limit: pageSize, Offset: offset})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list schemas for pruning: %w", err)
+		}
+		for _, s := range page.Data {
+			if managedSchemas[s.PublicID] {
+				continue
+			}
+			publicID := s.PublicID
+			changes = append(changes, Change{
+				Kind: KindSchema, Code: publicID, Action: ActionPrune,
+				apply: func(ctx context.Context, c *client.Client) error { return c.DeleteSchema(ctx, publicID) },
+			})
+		}
+		if !page.Pagination.HasMore {
+			break
+		}
+		offset += pageSize
+	}
+
+	return changes, nil
+}
+
+func contentSummary(content map[string]interface{}) (string, error) {
+	data, err := json.Marshal(content)
+	if err != nil {
+		return "", err
+	}
+	return client.CanonicalizeAndHash(data)
+}
+
+func ptrVal(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func equalJSON(a, b map[string]interface{}) bool {
+	aj, _ := json.Marshal(a)
+	bj, _ := json.Marshal(b)
+	return string(aj) == string(bj)
+}
@@ -0,0 +1,71 @@
+package bulk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/client"
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/config"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *client.Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	c, err := client.New(&config.Config{APIBaseURL: srv.URL, Timeout: 5}, client.WithMaxRetries(0))
+	if err != nil {
+		t.Fatalf("client.New: %v", err)
+	}
+	return c
+}
+
+// TestPlanPrunePaginatesSchemas is a regression test for planPrune's schema
+// page: a doc type/country with more referencing schemas than one page must
+// still have every one of them considered for pruning, not just the first
+// page's worth.
+func TestPlanPrunePaginatesSchemas(t *testing.T) {
+	const total = 150 // more than one pageSize-100 page
+	const pageSize = 100
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/admin/doc-types":
+			json.NewEncoder(w).Encode([]client.DocType{})
+		case "/admin/countries":
+			json.NewEncoder(w).Encode([]client.Country{})
+		case "/admin/schemas":
+			offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+			end := offset + pageSize
+			hasMore := end < total
+			if end > total {
+				end = total
+			}
+			data := make([]client.SchemaWithRelations, 0, end-offset)
+			for i := offset; i < end; i++ {
+				s := client.SchemaWithRelations{}
+				s.PublicID = "schema-" + strconv.Itoa(i)
+				data = append(data, s)
+			}
+			json.NewEncoder(w).Encode(client.PaginatedSchemaList{
+				Data:       data,
+				Pagination: client.Pagination{Total: total, Limit: pageSize, Offset: offset, HasMore: hasMore},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	p := NewPlanner(c)
+	changes, err := p.planPrune(context.Background(), map[string]bool{}, map[string]bool{}, map[string]bool{})
+	if err != nil {
+		t.Fatalf("planPrune: %v", err)
+	}
+	if len(changes) != total {
+		t.Errorf("got %d prune changes, want %d (pagination must cover every page)", len(changes), total)
+	}
+}
@@ -0,0 +1,172 @@
+package bulk
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/client"
+	"gopkg.in/yaml.v3"
+)
+
+// DocTypeResource is the on-disk representation of a doc type, written to
+// and read from doc-types/<code>.yaml.
+type DocTypeResource struct {
+	Code        string  `yaml:"code"`
+	Name        string  `yaml:"name"`
+	Description *string `yaml:"description,omitempty"`
+	IsActive    bool    `yaml:"isActive"`
+}
+
+// CountryResource is the on-disk representation of a country, written to
+// and read from countries/<code>.yaml.
+type CountryResource struct {
+	Code     string `yaml:"code"`
+	Name     string `yaml:"name"`
+	IsActive bool   `yaml:"isActive"`
+}
+
+// SchemaVersionResource is one entry in a SchemaResource's version history,
+// oldest first.
+type SchemaVersionResource struct {
+	Version         int                    `yaml:"version"`
+	PublicVersionID string                 `yaml:"publicVersionId"`
+	Status          client.Status          `yaml:"status"`
+	Content         map[string]interface{} `yaml:"content"`
+	Summary         string                 `yaml:"summary"`
+}
+
+// SchemaResource is the on-disk representation of a schema and its full
+// version history, written to and read from
+// schemas/<docType>/<country>/<publicId>.yaml. Applying a SchemaResource
+// only ever acts on its latest (last) version — see the package doc comment.
+type SchemaResource struct {
+	PublicID    string                  `yaml:"publicId"`
+	Name        string                  `yaml:"name"`
+	Description *string                 `yaml:"description,omitempty"`
+	DocTypeCode string                  `yaml:"docTypeCode"`
+	CountryCode string                  `yaml:"countryCode,omitempty"`
+	Visibility  client.Visibility       `yaml:"visibility"`
+	SchemaType  client.SchemaType       `yaml:"schemaType"`
+	CustomerID  string                  `yaml:"customerId,omitempty"`
+	Versions    []SchemaVersionResource `yaml:"versions"`
+}
+
+// Latest returns the resource's newest version, assuming Versions is
+// ordered oldest first (as Export writes it).
+func (s SchemaResource) Latest() SchemaVersionResource {
+	return s.Versions[len(s.Versions)-1]
+}
+
+// Tree is the set of resources loaded from, or about to be written to, a
+// bulk directory.
+type Tree struct {
+	DocTypes  []DocTypeResource
+	Countries []CountryResource
+	Schemas   []SchemaResource
+}
+
+// noCountryDir is the directory segment used for schemas with no country,
+// since an empty path segment isn't representable on disk.
+const noCountryDir = "_"
+
+func docTypePath(root, code string) string {
+	return filepath.Join(root, "doc-types", code+".yaml")
+}
+
+func countryPath(root, code string) string {
+	return filepath.Join(root, "countries", code+".yaml")
+}
+
+func schemaPath(root string, s SchemaResource) string {
+	country := s.CountryCode
+	if country == "" {
+		country = noCountryDir
+	}
+	return filepath.Join(root, "schemas", s.DocTypeCode, country, s.PublicID+".yaml")
+}
+
+// LoadTree reads a bulk directory written by Export (or hand-authored in the
+// same layout) into a Tree.
+func LoadTree(dir string) (Tree, error) {
+	var tree Tree
+
+	if err := loadYAMLDir(filepath.Join(dir, "doc-types"), func(data []byte, path string) error {
+		var dt DocTypeResource
+		if err := yaml.Unmarshal(data, &dt); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		tree.DocTypes = append(tree.DocTypes, dt)
+		return nil
+	}); err != nil {
+		return Tree{}, err
+	}
+
+	if err := loadYAMLDir(filepath.Join(dir, "countries"), func(data []byte, path string) error {
+		var c CountryResource
+		if err := yaml.Unmarshal(data, &c); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		tree.Countries = append(tree.Countries, c)
+		return nil
+	}); err != nil {
+		return Tree{}, err
+	}
+
+	if err := loadYAMLDir(filepath.Join(dir, "schemas"), func(data []byte, path string) error {
+		var s SchemaResource
+		if err := yaml.Unmarshal(data, &s); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		if len(s.Versions) == 0 {
+			return fmt.Errorf("%s: schema resource has no versions", path)
+		}
+		tree.Schemas = append(tree.Schemas, s)
+		return nil
+	}); err != nil {
+		return Tree{}, err
+	}
+
+	return tree, nil
+}
+
+// loadYAMLDir walks dir (a no-op if it doesn't exist) calling fn with the
+// contents of every *.yaml/*.yml file found, in a stable order.
+func loadYAMLDir(dir string, fn func(data []byte, path string) error) error {
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil
+	}
+
+	var paths []string
+	err := filepath.Walk(dir, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if fi.IsDir() {
+			return nil
+		}
+		ext := strings.ToLower(filepath.Ext(p))
+		if ext != ".yaml" && ext != ".yml" {
+			return nil
+		}
+		paths = append(paths, p)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to walk %s: %w", dir, err)
+	}
+	sort.Strings(paths)
+
+	for _, p := range paths {
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", p, err)
+		}
+		if err := fn(data, p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
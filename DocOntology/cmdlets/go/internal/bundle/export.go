@@ -0,0 +1,126 @@
+package bundle
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/client"
+)
+
+// ExportOptions configures an export run.
+type ExportOptions struct {
+	Filter Filter
+}
+
+// Export walks all schemas the caller can see and writes them to outDir as a
+// directory tree `docType/country/name@vN.json`, plus a manifest.json
+// describing the bundle.
+func Export(ctx context.Context, c *client.Client, outDir string, opts ExportOptions) (Manifest, error) {
+	manifest := Manifest{SchemaVersion: ManifestVersion, ExportedAt: time.Now()}
+
+	const pageSize = 100
+	offset := 0
+	for {
+		page, err := c.ListSchemas(ctx, &client.ListSchemasOptions{Limit: pageSize, Offset: offset})
+		if err != nil {
+			return manifest, fmt.Errorf("failed to list schemas: %w", err)
+		}
+
+		for _, s := range page.Data {
+			country := ""
+			if s.CountryCode != nil {
+				country = *s.CountryCode
+			}
+			if !opts.Filter.Matches(s.DocTypeCode, country) {
+				continue
+			}
+
+			full, err := c.GetSchema(ctx, s.PublicVersionID)
+			if err != nil {
+				return manifest, fmt.Errorf("failed to fetch %s: %w", s.PublicVersionID, err)
+			}
+
+			entry, err := writeSchemaFile(outDir, full)
+			if err != nil {
+				return manifest, err
+			}
+			manifest.Files = append(manifest.Files, entry)
+		}
+
+		if !page.Pagination.HasMore {
+			break
+		}
+		offset += pageSize
+	}
+
+	if err := writeManifest(outDir, manifest); err != nil {
+		return manifest, err
+	}
+	return manifest, nil
+}
+
+func writeSchemaFile(outDir string, s *client.SchemaWithRelations) (FileEntry, error) {
+	country := ""
+	if s.Country != nil {
+		country = s.Country.Code
+	}
+
+	relPath := filepath.Join(s.DocTypeCode, orDash(country), fmt.Sprintf("%s@v%d.json", s.Name, s.Version))
+	fullPath := filepath.Join(outDir, relPath)
+
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0o755); err != nil {
+		return FileEntry{}, fmt.Errorf("failed to create %s: %w", filepath.Dir(fullPath), err)
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return FileEntry{}, fmt.Errorf("failed to marshal %s: %w", s.PublicVersionID, err)
+	}
+	if err := os.WriteFile(fullPath, data, 0o644); err != nil {
+		return FileEntry{}, fmt.Errorf("failed to write %s: %w", fullPath, err)
+	}
+
+	return FileEntry{
+		Path:        relPath,
+		PublicID:    s.PublicID,
+		DocTypeCode: s.DocTypeCode,
+		CountryCode: country,
+		Version:     s.Version,
+		ContentHash: hashContent(s.Content),
+	}, nil
+}
+
+func writeManifest(outDir string, manifest Manifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(outDir, ManifestFileName), data, 0o644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return nil
+}
+
+// hashContent computes a deterministic SHA-256 digest of a schema's content,
+// used to detect whether an import needs a new version at all.
+func hashContent(content map[string]interface{}) string {
+	canonical, err := json.Marshal(content)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:])
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
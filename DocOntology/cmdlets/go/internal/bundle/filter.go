@@ -0,0 +1,48 @@
+package bundle
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Filter narrows a bundle export/import to schemas matching the given
+// doc-type and/or country codes. A zero-value Filter matches everything.
+type Filter struct {
+	DocType string
+	Country string
+}
+
+// ParseFilter parses the `--filter docType=INVOICE,country=PT` flag syntax.
+func ParseFilter(s string) (Filter, error) {
+	var f Filter
+	if s == "" {
+		return f, nil
+	}
+	for _, pair := range strings.Split(s, ",") {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 {
+			return f, fmt.Errorf("invalid filter term %q (expected key=value)", pair)
+		}
+		switch strings.ToLower(strings.TrimSpace(kv[0])) {
+		case "doctype", "doc-type":
+			f.DocType = kv[1]
+		case "country":
+			f.Country = kv[1]
+		default:
+			return f, fmt.Errorf("unknown filter key %q (supported: docType, country)", kv[0])
+		}
+	}
+	return f, nil
+}
+
+// Matches reports whether a schema with the given doc-type/country codes
+// passes this filter.
+func (f Filter) Matches(docType, country string) bool {
+	if f.DocType != "" && f.DocType != docType {
+		return false
+	}
+	if f.Country != "" && f.Country != country {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,200 @@
+package bundle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/client"
+)
+
+// ImportOptions configures an import run.
+type ImportOptions struct {
+	Filter          Filter
+	CreateMissing   bool
+	DryRun          bool
+	ContinueOnError bool
+}
+
+// FailedEntry records a schema that failed to import and why.
+type FailedEntry struct {
+	Name  string
+	Error string
+}
+
+// ImportResult summarizes the reconciliation an import performed.
+type ImportResult struct {
+	Created   []string
+	Updated   []string
+	Unchanged []string
+	Skipped   []string
+	Failed    []FailedEntry
+}
+
+// Import reads a bundle's manifest and reconciles each schema against the
+// target server: create if absent, no-op if the content hash matches, or
+// create a new version otherwise.
+func Import(ctx context.Context, c *client.Client, dir string, opts ImportOptions) (ImportResult, error) {
+	var result ImportResult
+
+	manifest, err := readManifest(dir)
+	if err != nil {
+		return result, err
+	}
+
+	docTypeCache := map[string]bool{}
+	countryCache := map[string]bool{}
+
+	for _, entry := range manifest.Files {
+		if !opts.Filter.Matches(entry.DocTypeCode, entry.CountryCode) {
+			result.Skipped = append(result.Skipped, entry.Path)
+			continue
+		}
+
+		if err := importOne(ctx, c, dir, entry, opts, docTypeCache, countryCache, &result); err != nil {
+			if !opts.ContinueOnError {
+				return result, err
+			}
+			result.Failed = append(result.Failed, FailedEntry{Name: entry.Path, Error: err.Error()})
+		}
+	}
+
+	return result, nil
+}
+
+func importOne(ctx context.Context, c *client.Client, dir string, entry FileEntry, opts ImportOptions, docTypeCache, countryCache map[string]bool, result *ImportResult) error {
+	var s client.SchemaWithRelations
+	data, err := os.ReadFile(filepath.Join(dir, entry.Path))
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", entry.Path, err)
+	}
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", entry.Path, err)
+	}
+
+	if opts.CreateMissing {
+		if err := ensureDocType(ctx, c, s, docTypeCache); err != nil {
+			return err
+		}
+		if err := ensureCountry(ctx, c, s, countryCache); err != nil {
+			return err
+		}
+	}
+
+	existing, err := findExisting(ctx, c, s)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case existing == nil:
+		result.Created = append(result.Created, s.PublicID)
+		if opts.DryRun {
+			return nil
+		}
+		if _, err := c.CreateSchema(ctx, toCreateRequest(s)); err != nil {
+			return fmt.Errorf("failed to create %s: %w", s.Name, err)
+		}
+	case hashContent(existing.Content) == entry.ContentHash:
+		result.Unchanged = append(result.Unchanged, existing.PublicID)
+	default:
+		result.Updated = append(result.Updated, existing.PublicID)
+		if opts.DryRun {
+			return nil
+		}
+		if _, err := c.UpdateSchema(ctx, existing.PublicID, toUpdateRequest(s)); err != nil {
+			return fmt.Errorf("failed to update %s: %w", existing.PublicID, err)
+		}
+	}
+	return nil
+}
+
+func readManifest(dir string) (Manifest, error) {
+	var manifest Manifest
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFileName))
+	if err != nil {
+		return manifest, fmt.Errorf("failed to read %s: %w", ManifestFileName, err)
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return manifest, fmt.Errorf("failed to parse %s: %w", ManifestFileName, err)
+	}
+	return manifest, nil
+}
+
+func ensureDocType(ctx context.Context, c *client.Client, s client.SchemaWithRelations, cache map[string]bool) error {
+	if cache[s.DocTypeCode] {
+		return nil
+	}
+	if _, err := c.GetDocType(ctx, s.DocTypeCode); err == nil {
+		cache[s.DocTypeCode] = true
+		return nil
+	} else if !client.IsNotFound(err) {
+		return fmt.Errorf("failed to look up doc type %s: %w", s.DocTypeCode, err)
+	}
+	if _, err := c.CreateDocType(ctx, &client.CreateDocTypeRequest{Code: s.DocTypeCode, Name: s.DocType.Name}); err != nil {
+		return fmt.Errorf("failed to create missing doc type %s: %w", s.DocTypeCode, err)
+	}
+	cache[s.DocTypeCode] = true
+	return nil
+}
+
+func ensureCountry(ctx context.Context, c *client.Client, s client.SchemaWithRelations, cache map[string]bool) error {
+	if s.Country == nil || cache[s.Country.Code] {
+		return nil
+	}
+	if _, err := c.GetCountry(ctx, s.Country.Code); err == nil {
+		cache[s.Country.Code] = true
+		return nil
+	} else if !client.IsNotFound(err) {
+		return fmt.Errorf("failed to look up country %s: %w", s.Country.Code, err)
+	}
+	if _, err := c.CreateCountry(ctx, &client.CreateCountryRequest{Code: s.Country.Code, Name: s.Country.Name}); err != nil {
+		return fmt.Errorf("failed to create missing country %s: %w", s.Country.Code, err)
+	}
+	cache[s.Country.Code] = true
+	return nil
+}
+
+// findExisting looks up a schema on the target server by the same identity
+// an apply-style reconciliation uses: name + doc type + country + customer.
+func findExisting(ctx context.Context, c *client.Client, s client.SchemaWithRelations) (*client.SchemaWithRelations, error) {
+	opts := &client.ListSchemasOptions{DocType: &s.DocTypeCode, Limit: 100}
+	if s.CountryCode != nil {
+		opts.Country = s.CountryCode
+	}
+	if s.CustomerID != nil {
+		opts.CustomerID = s.CustomerID
+	}
+	page, err := c.ListSchemas(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search for existing schema: %w", err)
+	}
+	for _, candidate := range page.Data {
+		if candidate.Name == s.Name {
+			found := candidate
+			return &found, nil
+		}
+	}
+	return nil, nil
+}
+
+func toCreateRequest(s client.SchemaWithRelations) *client.CreateSchemaRequest {
+	return &client.CreateSchemaRequest{
+		Name:        s.Name,
+		Description: s.Description,
+		Content:     s.Content,
+		DocTypeCode: s.DocTypeCode,
+		CountryCode: s.CountryCode,
+		Visibility:  &s.Visibility,
+		SchemaType:  &s.SchemaType,
+		CustomerID:  s.CustomerID,
+	}
+}
+
+func toUpdateRequest(s client.SchemaWithRelations) *client.UpdateSchemaRequest {
+	return &client.UpdateSchemaRequest{
+		Content: s.Content,
+	}
+}
@@ -0,0 +1,29 @@
+// Package bundle reads and writes directory-tree bundles of schemas, so they
+// can be promoted between environments (e.g. dev -> prod) reproducibly.
+package bundle
+
+import "time"
+
+// ManifestVersion is the bundle format version written to manifest.json.
+const ManifestVersion = 1
+
+// FileEntry records one exported schema file and its content hash, so
+// Import can detect schemas that haven't changed since the bundle was cut.
+type FileEntry struct {
+	Path        string `json:"path"`
+	PublicID    string `json:"publicId"`
+	DocTypeCode string `json:"docTypeCode"`
+	CountryCode string `json:"countryCode,omitempty"`
+	Version     int    `json:"version"`
+	ContentHash string `json:"contentHash"`
+}
+
+// Manifest describes a bundle's contents.
+type Manifest struct {
+	SchemaVersion int         `json:"schemaVersion"`
+	ExportedAt    time.Time   `json:"exportedAt"`
+	Files         []FileEntry `json:"files"`
+}
+
+// ManifestFileName is the name of the manifest file at the bundle root.
+const ManifestFileName = "manifest.json"
@@ -2,19 +2,19 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http"
 	"net/url"
-	"os"
-	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/miguel-bandeira-infosistema/schemactl/internal/config"
+	"golang.org/x/time/rate"
 )
 
 // Client is the HTTP client for the Schema Registry API
@@ -22,57 +22,95 @@ type Client struct {
 	baseURL    string
 	apiKey     string
 	httpClient *http.Client
+
+	// maxRetries and limiter configure doWithRetry's retry/rate-limit
+	// behavior; see WithMaxRetries and WithRateLimit.
+	maxRetries int
+	limiter    *rate.Limiter
 }
 
-// New creates a new API client
-func New(cfg *config.Config) *Client {
-	return &Client{
-		baseURL: strings.TrimSuffix(cfg.APIBaseURL, "/"),
-		apiKey:  cfg.APIKey,
-		httpClient: &http.Client{
-			Timeout: time.Duration(cfg.Timeout) * time.Second,
-		},
+// New creates a new API client. Retry and rate-limit behavior default to
+// cfg.MaxRetries/cfg.RateLimit, overridable per-call with options like
+// WithMaxRetries or WithRateLimit. If cfg asks for a Unix domain socket or
+// mutual TLS (APISocketPath/ClientCertFile/CACertFile/InsecureSkipVerify),
+// building that transport can fail (e.g. an unreadable cert file), hence the
+// error return.
+func New(cfg *config.Config, opts ...Option) (*Client, error) {
+	transport, err := buildTransport(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient := &http.Client{Timeout: time.Duration(cfg.Timeout) * time.Second}
+	if transport != nil {
+		// A nil *http.Transport assigned directly to the Transport field would
+		// be a non-nil interface wrapping a nil pointer, which panics on the
+		// first request instead of falling back to http.DefaultTransport.
+		httpClient.Transport = transport
+	}
+
+	c := &Client{
+		baseURL:    strings.TrimSuffix(cfg.APIBaseURL, "/"),
+		apiKey:     cfg.APIKey,
+		httpClient: httpClient,
+		maxRetries: cfg.MaxRetries,
+		limiter:    newLimiter(cfg.RateLimit),
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// doRequest performs an HTTP request and decodes the response, retrying
+// transient failures through doWithRetry. ctx bounds the whole call,
+// including retries: a deadline on ctx overrides the client's static
+// Timeout if it would fire first.
+func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}, result interface{}) error {
+	return c.doRequestHeaders(ctx, method, path, body, result, nil)
 }
 
-// doRequest performs an HTTP request and decodes the response
-func (c *Client) doRequest(method, path string, body interface{}, result interface{}) error {
-	var reqBody io.Reader
+// doRequestHeaders is doRequest plus extra request headers (e.g.
+// X-Schema-Summary on schema writes), set after Content-Type/Authorization
+// so callers can't accidentally clobber them.
+func (c *Client) doRequestHeaders(ctx context.Context, method, path string, body interface{}, result interface{}, headers map[string]string) error {
+	var jsonBody []byte
 	if body != nil {
-		jsonBody, err := json.Marshal(body)
+		var err error
+		jsonBody, err = json.Marshal(body)
 		if err != nil {
 			return fmt.Errorf("failed to marshal request body: %w", err)
 		}
-		reqBody = bytes.NewReader(jsonBody)
-	}
-
-	req, err := http.NewRequest(method, c.baseURL+path, reqBody)
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	if c.apiKey != "" {
-		req.Header.Set("Authorization", "Bearer "+c.apiKey)
-	}
-
-	resp, err := c.httpClient.Do(req)
+	status, respBody, err := c.doWithRetry(ctx, func(ctx context.Context) (*http.Request, error) {
+		var reqBody io.Reader
+		if jsonBody != nil {
+			reqBody = bytes.NewReader(jsonBody)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reqBody)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if c.apiKey != "" {
+			req.Header.Set("Authorization", "Bearer "+c.apiKey)
+		}
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		return req, nil
+	})
 	if err != nil {
 		return fmt.Errorf("request failed: %w", err)
 	}
-	defer resp.Body.Close()
-
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
-	}
 
-	if resp.StatusCode >= 400 {
+	if status >= 400 {
 		var errResp ErrorResponse
 		if err := json.Unmarshal(respBody, &errResp); err == nil && errResp.Error != "" {
-			return fmt.Errorf("API error (%d): %s", resp.StatusCode, errResp.Error)
+			return &APIError{StatusCode: status, Message: errResp.Error}
 		}
-		return fmt.Errorf("API error (%d): %s", resp.StatusCode, string(respBody))
+		return &APIError{StatusCode: status, Message: string(respBody)}
 	}
 
 	if result != nil && len(respBody) > 0 {
@@ -85,115 +123,143 @@ func (c *Client) doRequest(method, path string, body interface{}, result interfa
 }
 
 // Health checks the API health
-func (c *Client) Health() (*HealthResponse, error) {
+func (c *Client) Health(ctx context.Context) (*HealthResponse, error) {
 	var result HealthResponse
-	if err := c.doRequest(http.MethodGet, "/health", nil, &result); err != nil {
+	if err := c.doRequest(ctx, http.MethodGet, "/health", nil, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
 }
 
 // GetReferenceData gets all reference data
-func (c *Client) GetReferenceData() (*ReferenceDataResponse, error) {
+func (c *Client) GetReferenceData(ctx context.Context) (*ReferenceDataResponse, error) {
 	var result ReferenceDataResponse
-	if err := c.doRequest(http.MethodGet, "/reference-data", nil, &result); err != nil {
+	if err := c.doRequest(ctx, http.MethodGet, "/reference-data", nil, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
 }
 
 // ListDocTypes lists all doc types (admin endpoint includes inactive)
-func (c *Client) ListDocTypes(includeInactive bool) ([]DocType, error) {
+func (c *Client) ListDocTypes(ctx context.Context, includeInactive bool) ([]DocType, error) {
+	path := "/doc-types"
+	if includeInactive {
+		path = "/admin/doc-types"
+	}
+	var result []DocType
+	if err := c.doRequest(ctx, http.MethodGet, path, nil, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ListDocTypesFiltered lists doc types with optional server-side filter,
+// field-projection, and sort query parameters — build params with
+// internal/query.BuildParams. Servers that don't yet understand these
+// parameters will just ignore them; pair with a client-side fallback (see
+// "doc-types list --client-filter") for those.
+func (c *Client) ListDocTypesFiltered(ctx context.Context, includeInactive bool, params url.Values) ([]DocType, error) {
 	path := "/doc-types"
 	if includeInactive {
 		path = "/admin/doc-types"
 	}
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
 	var result []DocType
-	if err := c.doRequest(http.MethodGet, path, nil, &result); err != nil {
+	if err := c.doRequest(ctx, http.MethodGet, path, nil, &result); err != nil {
 		return nil, err
 	}
 	return result, nil
 }
 
 // GetDocType gets a doc type by code
-func (c *Client) GetDocType(code string) (*DocType, error) {
+func (c *Client) GetDocType(ctx context.Context, code string) (*DocType, error) {
 	var result DocType
-	if err := c.doRequest(http.MethodGet, "/admin/doc-types/"+url.PathEscape(code), nil, &result); err != nil {
+	if err := c.doRequest(ctx, http.MethodGet, "/admin/doc-types/"+url.PathEscape(code), nil, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
 }
 
 // CreateDocType creates a new doc type
-func (c *Client) CreateDocType(req *CreateDocTypeRequest) (*DocType, error) {
+func (c *Client) CreateDocType(ctx context.Context, req *CreateDocTypeRequest) (*DocType, error) {
 	var result DocType
-	if err := c.doRequest(http.MethodPost, "/admin/doc-types", req, &result); err != nil {
+	if err := c.doRequest(ctx, http.MethodPost, "/admin/doc-types", req, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
 }
 
 // UpdateDocType updates a doc type
-func (c *Client) UpdateDocType(code string, req *UpdateDocTypeRequest) (*DocType, error) {
+func (c *Client) UpdateDocType(ctx context.Context, code string, req *UpdateDocTypeRequest) (*DocType, error) {
 	var result DocType
-	if err := c.doRequest(http.MethodPatch, "/admin/doc-types/"+url.PathEscape(code), req, &result); err != nil {
+	if err := c.doRequest(ctx, http.MethodPatch, "/admin/doc-types/"+url.PathEscape(code), req, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
 }
 
 // DeleteDocType soft deletes a doc type
-func (c *Client) DeleteDocType(code string) error {
-	return c.doRequest(http.MethodDelete, "/admin/doc-types/"+url.PathEscape(code), nil, nil)
+func (c *Client) DeleteDocType(ctx context.Context, code string) error {
+	return c.doRequest(ctx, http.MethodDelete, "/admin/doc-types/"+url.PathEscape(code), nil, nil)
+}
+
+// HardDeleteDocType permanently deletes a doc type, bypassing the default
+// soft delete. The caller is responsible for checking it's safe to do so
+// first — see "doc-types delete --hard", which refuses unless the doc type
+// has no active schemas referencing it or --cascade was given.
+func (c *Client) HardDeleteDocType(ctx context.Context, code string) error {
+	return c.doRequest(ctx, http.MethodDelete, "/admin/doc-types/"+url.PathEscape(code)+"?force=true", nil, nil)
 }
 
 // ListCountries lists all countries (admin endpoint includes inactive)
-func (c *Client) ListCountries(includeInactive bool) ([]Country, error) {
+func (c *Client) ListCountries(ctx context.Context, includeInactive bool) ([]Country, error) {
 	path := "/countries"
 	if includeInactive {
 		path = "/admin/countries"
 	}
 	var result []Country
-	if err := c.doRequest(http.MethodGet, path, nil, &result); err != nil {
+	if err := c.doRequest(ctx, http.MethodGet, path, nil, &result); err != nil {
 		return nil, err
 	}
 	return result, nil
 }
 
 // GetCountry gets a country by code
-func (c *Client) GetCountry(code string) (*Country, error) {
+func (c *Client) GetCountry(ctx context.Context, code string) (*Country, error) {
 	var result Country
-	if err := c.doRequest(http.MethodGet, "/admin/countries/"+url.PathEscape(code), nil, &result); err != nil {
+	if err := c.doRequest(ctx, http.MethodGet, "/admin/countries/"+url.PathEscape(code), nil, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
 }
 
 // CreateCountry creates a new country
-func (c *Client) CreateCountry(req *CreateCountryRequest) (*Country, error) {
+func (c *Client) CreateCountry(ctx context.Context, req *CreateCountryRequest) (*Country, error) {
 	var result Country
-	if err := c.doRequest(http.MethodPost, "/admin/countries", req, &result); err != nil {
+	if err := c.doRequest(ctx, http.MethodPost, "/admin/countries", req, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
 }
 
 // UpdateCountry updates a country
-func (c *Client) UpdateCountry(code string, req *UpdateCountryRequest) (*Country, error) {
+func (c *Client) UpdateCountry(ctx context.Context, code string, req *UpdateCountryRequest) (*Country, error) {
 	var result Country
-	if err := c.doRequest(http.MethodPatch, "/admin/countries/"+url.PathEscape(code), req, &result); err != nil {
+	if err := c.doRequest(ctx, http.MethodPatch, "/admin/countries/"+url.PathEscape(code), req, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
 }
 
 // DeleteCountry soft deletes a country
-func (c *Client) DeleteCountry(code string) error {
-	return c.doRequest(http.MethodDelete, "/admin/countries/"+url.PathEscape(code), nil, nil)
+func (c *Client) DeleteCountry(ctx context.Context, code string) error {
+	return c.doRequest(ctx, http.MethodDelete, "/admin/countries/"+url.PathEscape(code), nil, nil)
 }
 
 // ListSchemas lists schemas with optional filtering
-func (c *Client) ListSchemas(opts *ListSchemasOptions) (*PaginatedSchemaList, error) {
+func (c *Client) ListSchemas(ctx context.Context, opts *ListSchemasOptions) (*PaginatedSchemaList, error) {
 	params := url.Values{}
 	if opts != nil {
 		if opts.Status != nil {
@@ -225,143 +291,153 @@ func (c *Client) ListSchemas(opts *ListSchemasOptions) (*PaginatedSchemaList, er
 	}
 
 	var result PaginatedSchemaList
-	if err := c.doRequest(http.MethodGet, path, nil, &result); err != nil {
+	if err := c.doRequest(ctx, http.MethodGet, path, nil, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
 }
 
 // GetSchema gets a schema by ID (publicId or publicVersionId)
-func (c *Client) GetSchema(id string) (*SchemaWithRelations, error) {
+func (c *Client) GetSchema(ctx context.Context, id string) (*SchemaWithRelations, error) {
 	var result SchemaWithRelations
-	if err := c.doRequest(http.MethodGet, "/admin/schemas/"+url.PathEscape(id), nil, &result); err != nil {
+	if err := c.doRequest(ctx, http.MethodGet, "/admin/schemas/"+url.PathEscape(id), nil, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
 }
 
 // GetSchemaVersion gets a specific schema version by version ID
-func (c *Client) GetSchemaVersion(versionID string) (*SchemaWithRelations, error) {
+func (c *Client) GetSchemaVersion(ctx context.Context, versionID string) (*SchemaWithRelations, error) {
 	var result SchemaWithRelations
-	if err := c.doRequest(http.MethodGet, "/admin/schemas/versions/"+url.PathEscape(versionID), nil, &result); err != nil {
+	if err := c.doRequest(ctx, http.MethodGet, "/admin/schemas/versions/"+url.PathEscape(versionID), nil, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
 }
 
 // GetSchemaVersions gets all versions of a schema
-func (c *Client) GetSchemaVersions(id string) ([]SchemaWithRelations, error) {
+func (c *Client) GetSchemaVersions(ctx context.Context, id string) ([]SchemaWithRelations, error) {
 	var result []SchemaWithRelations
-	if err := c.doRequest(http.MethodGet, "/admin/schemas/"+url.PathEscape(id)+"/versions", nil, &result); err != nil {
+	if err := c.doRequest(ctx, http.MethodGet, "/admin/schemas/"+url.PathEscape(id)+"/versions", nil, &result); err != nil {
 		return nil, err
 	}
 	return result, nil
 }
 
-// CreateSchema creates a new schema
-func (c *Client) CreateSchema(req *CreateSchemaRequest) (*SchemaWithRelations, error) {
+// GetSchemaVersionByNumber gets a specific version of a schema by its
+// publicId and version number, rather than by publicVersionId.
+func (c *Client) GetSchemaVersionByNumber(ctx context.Context, publicID string, version int) (*SchemaWithRelations, error) {
+	versions, err := c.GetSchemaVersions(ctx, publicID)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range versions {
+		if v.Version == version {
+			return &v, nil
+		}
+	}
+	return nil, fmt.Errorf("version %d not found for schema %s", version, publicID)
+}
+
+// CreateSchema creates a new schema. The content's SHA-256 summary (see
+// CanonicalizeAndHash) is sent as an X-Schema-Summary header so the server
+// can store it alongside the schema for later integrity checks.
+func (c *Client) CreateSchema(ctx context.Context, req *CreateSchemaRequest) (*SchemaWithRelations, error) {
+	headers, err := contentSummaryHeader(req.Content)
+	if err != nil {
+		return nil, err
+	}
 	var result SchemaWithRelations
-	if err := c.doRequest(http.MethodPost, "/admin/schemas", req, &result); err != nil {
+	if err := c.doRequestHeaders(ctx, http.MethodPost, "/admin/schemas", req, &result, headers); err != nil {
 		return nil, err
 	}
 	return &result, nil
 }
 
-// UpdateSchema updates a schema
-func (c *Client) UpdateSchema(id string, req *UpdateSchemaRequest) (*SchemaWithRelations, error) {
+// UpdateSchema updates a schema. When req.Content is set, its SHA-256
+// summary is sent as an X-Schema-Summary header, same as CreateSchema.
+func (c *Client) UpdateSchema(ctx context.Context, id string, req *UpdateSchemaRequest) (*SchemaWithRelations, error) {
+	headers, err := contentSummaryHeader(req.Content)
+	if err != nil {
+		return nil, err
+	}
 	var result SchemaWithRelations
-	if err := c.doRequest(http.MethodPatch, "/admin/schemas/"+url.PathEscape(id), req, &result); err != nil {
+	if err := c.doRequestHeaders(ctx, http.MethodPatch, "/admin/schemas/"+url.PathEscape(id), req, &result, headers); err != nil {
 		return nil, err
 	}
 	return &result, nil
 }
 
 // ActivateSchema activates a draft schema
-func (c *Client) ActivateSchema(id string) (*SchemaWithRelations, error) {
+func (c *Client) ActivateSchema(ctx context.Context, id string) (*SchemaWithRelations, error) {
 	var result SchemaWithRelations
-	if err := c.doRequest(http.MethodPost, "/admin/schemas/"+url.PathEscape(id)+"/activate", nil, &result); err != nil {
+	if err := c.doRequest(ctx, http.MethodPost, "/admin/schemas/"+url.PathEscape(id)+"/activate", nil, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
 }
 
 // DeprecateSchema deprecates an active schema
-func (c *Client) DeprecateSchema(id string) (*SchemaWithRelations, error) {
+func (c *Client) DeprecateSchema(ctx context.Context, id string) (*SchemaWithRelations, error) {
 	var result SchemaWithRelations
-	if err := c.doRequest(http.MethodPost, "/admin/schemas/"+url.PathEscape(id)+"/deprecate", nil, &result); err != nil {
+	if err := c.doRequest(ctx, http.MethodPost, "/admin/schemas/"+url.PathEscape(id)+"/deprecate", nil, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
 }
 
 // DeleteSchema deletes a draft schema
-func (c *Client) DeleteSchema(id string) error {
-	return c.doRequest(http.MethodDelete, "/admin/schemas/"+url.PathEscape(id), nil, nil)
+func (c *Client) DeleteSchema(ctx context.Context, id string) error {
+	return c.doRequest(ctx, http.MethodDelete, "/admin/schemas/"+url.PathEscape(id), nil, nil)
 }
 
 // FindBestSchema finds the best matching schema
-func (c *Client) FindBestSchema(req *FindBestRequest) (*FindBestResponse, error) {
+func (c *Client) FindBestSchema(ctx context.Context, req *FindBestRequest) (*FindBestResponse, error) {
 	var result FindBestResponse
-	if err := c.doRequest(http.MethodPost, "/schemas/find-best", req, &result); err != nil {
+	if err := c.doRequest(ctx, http.MethodPost, "/schemas/find-best", req, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
 }
 
-// MatchSchema uploads a file and finds a matching schema
-func (c *Client) MatchSchema(filePath string, customerID *string) (*ExtractResponse, error) {
-	file, err := os.Open(filePath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open file: %w", err)
-	}
-	defer file.Close()
-
-	body := &bytes.Buffer{}
-	writer := multipart.NewWriter(body)
-
-	part, err := writer.CreateFormFile("file", filepath.Base(filePath))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create form file: %w", err)
-	}
-
-	if _, err := io.Copy(part, file); err != nil {
-		return nil, fmt.Errorf("failed to copy file: %w", err)
-	}
-
-	if err := writer.Close(); err != nil {
-		return nil, fmt.Errorf("failed to close writer: %w", err)
-	}
-
-	req, err := http.NewRequest(http.MethodPost, c.baseURL+"/schemas/extract", body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Content-Type", writer.FormDataContentType())
-	if c.apiKey != "" {
-		req.Header.Set("Authorization", "Bearer "+c.apiKey)
-	}
-	if customerID != nil {
-		req.Header.Set("X-Customer-Id", *customerID)
-	}
-
-	resp, err := c.httpClient.Do(req)
+// MatchSchema uploads a file and finds a matching schema. The file is
+// streamed into the multipart body via an io.Pipe rather than buffered into
+// memory first, so large scans don't need to fit in RAM before the request
+// even starts sending; progress (if non-nil) is called as bytes leave the
+// pipe. ctx is checked between chunks, so a cancelled or expired context
+// tears the pipe down and aborts the upload instead of running it to
+// completion.
+func (c *Client) MatchSchema(ctx context.Context, filePath string, customerID *string, progress ProgressFunc) (*ExtractResponse, error) {
+	status, respBody, err := c.doWithRetry(ctx, func(ctx context.Context) (*http.Request, error) {
+		body, contentType, contentLength, err := newMultipartUpload(ctx, filePath, "file", nil, nil, progress)
+		if err != nil {
+			return nil, err
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/schemas/extract", body)
+		if err != nil {
+			body.Close()
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		req.ContentLength = contentLength
+		req.Header.Set("Content-Type", contentType)
+		if c.apiKey != "" {
+			req.Header.Set("Authorization", "Bearer "+c.apiKey)
+		}
+		if customerID != nil {
+			req.Header.Set("X-Customer-Id", *customerID)
+		}
+		return req, nil
+	})
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
-	defer resp.Body.Close()
 
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	if resp.StatusCode >= 400 {
+	if status >= 400 {
 		var errResp ErrorResponse
 		if err := json.Unmarshal(respBody, &errResp); err == nil && errResp.Error != "" {
-			return nil, fmt.Errorf("API error (%d): %s", resp.StatusCode, errResp.Error)
+			return nil, &APIError{StatusCode: status, Message: errResp.Error}
 		}
-		return nil, fmt.Errorf("API error (%d): %s", resp.StatusCode, string(respBody))
+		return nil, &APIError{StatusCode: status, Message: string(respBody)}
 	}
 
 	var result ExtractResponse
@@ -372,83 +448,81 @@ func (c *Client) MatchSchema(filePath string, customerID *string) (*ExtractRespo
 	return &result, nil
 }
 
-// GenerateSchema generates a schema from a document using LLM
-func (c *Client) GenerateSchema(req *GenerateSchemaRequest) (*GenerateResponse, error) {
-	body := &bytes.Buffer{}
-	writer := multipart.NewWriter(body)
-
-	// Add required fields
-	if err := writer.WriteField("docTypeCode", req.DocTypeCode); err != nil {
-		return nil, fmt.Errorf("failed to write docTypeCode: %w", err)
-	}
-	if err := writer.WriteField("countryCode", req.CountryCode); err != nil {
-		return nil, fmt.Errorf("failed to write countryCode: %w", err)
+// GenerateSchema generates a schema from a document using LLM. When
+// req.FilePath is set, the file is streamed into the multipart body via an
+// io.Pipe rather than buffered into memory first, and progress (if non-nil)
+// is called as bytes leave the pipe; req.Text requests are small enough to
+// build in memory as before. ctx is checked between chunks of the file
+// upload, so a cancelled or expired context tears the pipe down and aborts
+// the upload instead of running it to completion.
+func (c *Client) GenerateSchema(ctx context.Context, req *GenerateSchemaRequest, progress ProgressFunc) (*GenerateResponse, error) {
+	if req.FilePath == "" && req.Text == "" {
+		return nil, fmt.Errorf("either FilePath or Text must be provided")
 	}
 
-	// Add file or text
-	if req.FilePath != "" {
-		file, err := os.Open(req.FilePath)
-		if err != nil {
-			return nil, fmt.Errorf("failed to open file: %w", err)
+	status, respBody, err := c.doWithRetry(ctx, func(ctx context.Context) (*http.Request, error) {
+		var body io.ReadCloser
+		var contentType string
+		var contentLength int64
+
+		if req.FilePath != "" {
+			useOCR := "true"
+			if !req.UseOCR {
+				useOCR = "false"
+			}
+			before := []multipartField{
+				{name: "docTypeCode", value: req.DocTypeCode},
+				{name: "countryCode", value: req.CountryCode},
+			}
+			after := []multipartField{{name: "useOCR", value: useOCR}}
+
+			var err error
+			body, contentType, contentLength, err = newMultipartUpload(ctx, req.FilePath, "file", before, after, progress)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			buf := &bytes.Buffer{}
+			writer := multipart.NewWriter(buf)
+			if err := writer.WriteField("docTypeCode", req.DocTypeCode); err != nil {
+				return nil, fmt.Errorf("failed to write docTypeCode: %w", err)
+			}
+			if err := writer.WriteField("countryCode", req.CountryCode); err != nil {
+				return nil, fmt.Errorf("failed to write countryCode: %w", err)
+			}
+			if err := writer.WriteField("text", req.Text); err != nil {
+				return nil, fmt.Errorf("failed to write text: %w", err)
+			}
+			if err := writer.Close(); err != nil {
+				return nil, fmt.Errorf("failed to close writer: %w", err)
+			}
+			body = io.NopCloser(buf)
+			contentType = writer.FormDataContentType()
+			contentLength = int64(buf.Len())
 		}
-		defer file.Close()
 
-		part, err := writer.CreateFormFile("file", filepath.Base(req.FilePath))
+		httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/schemas/generate", body)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create form file: %w", err)
+			body.Close()
+			return nil, fmt.Errorf("failed to create request: %w", err)
 		}
-
-		if _, err := io.Copy(part, file); err != nil {
-			return nil, fmt.Errorf("failed to copy file: %w", err)
-		}
-
-		// Add useOCR field
-		useOCR := "true"
-		if !req.UseOCR {
-			useOCR = "false"
+		httpReq.ContentLength = contentLength
+		httpReq.Header.Set("Content-Type", contentType)
+		if c.apiKey != "" {
+			httpReq.Header.Set("Authorization", "Bearer "+c.apiKey)
 		}
-		if err := writer.WriteField("useOCR", useOCR); err != nil {
-			return nil, fmt.Errorf("failed to write useOCR: %w", err)
-		}
-	} else if req.Text != "" {
-		if err := writer.WriteField("text", req.Text); err != nil {
-			return nil, fmt.Errorf("failed to write text: %w", err)
-		}
-	} else {
-		return nil, fmt.Errorf("either FilePath or Text must be provided")
-	}
-
-	if err := writer.Close(); err != nil {
-		return nil, fmt.Errorf("failed to close writer: %w", err)
-	}
-
-	httpReq, err := http.NewRequest(http.MethodPost, c.baseURL+"/schemas/generate", body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	httpReq.Header.Set("Content-Type", writer.FormDataContentType())
-	if c.apiKey != "" {
-		httpReq.Header.Set("Authorization", "Bearer "+c.apiKey)
-	}
-
-	resp, err := c.httpClient.Do(httpReq)
+		return httpReq, nil
+	})
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
-	defer resp.Body.Close()
-
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
 
-	if resp.StatusCode >= 400 {
+	if status >= 400 {
 		var errResp ErrorResponse
 		if err := json.Unmarshal(respBody, &errResp); err == nil && errResp.Error != "" {
-			return nil, fmt.Errorf("API error (%d): %s", resp.StatusCode, errResp.Error)
+			return nil, fmt.Errorf("API error (%d): %s", status, errResp.Error)
 		}
-		return nil, fmt.Errorf("API error (%d): %s", resp.StatusCode, string(respBody))
+		return nil, fmt.Errorf("API error (%d): %s", status, string(respBody))
 	}
 
 	var result GenerateResponse
@@ -0,0 +1,36 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// APIError is returned when the API responds with a non-2xx status. Having
+// the status code available (rather than just a formatted string) lets
+// callers like the batch match driver tell a transient server failure (5xx,
+// worth retrying) from a client mistake (4xx, not worth retrying).
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API error (%d): %s", e.StatusCode, e.Message)
+}
+
+// Retryable reports whether the error is a transient server-side failure.
+func (e *APIError) Retryable() bool {
+	return e.StatusCode >= 500
+}
+
+// IsNotFound reports whether err is an APIError for a 404 response, i.e. the
+// resource genuinely doesn't exist rather than some other failure (a 5xx, a
+// network error) that happened to surface where an existence check is made.
+// Callers resolving "does this already exist?" must use this rather than
+// treating any error as absence, or a transient failure reads as "create
+// it" / "nothing to delete".
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// GetWithETag performs a conditional GET: if etag is non-empty it's sent as
+// If-None-Match, so a watch loop can poll without re-decoding (or
+// re-rendering) a response that hasn't changed. A 304 reports
+// notModified=true and leaves result untouched; otherwise result is decoded
+// and the response's own ETag (if any) is returned for the next call.
+func (c *Client) GetWithETag(ctx context.Context, path, etag string, result interface{}) (newETag string, notModified bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to create request: %w", err)
+	}
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", false, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return etag, true, nil
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(respBody, &errResp); err == nil && errResp.Error != "" {
+			return "", false, fmt.Errorf("API error (%d): %s", resp.StatusCode, errResp.Error)
+		}
+		return "", false, fmt.Errorf("API error (%d): %s", resp.StatusCode, string(respBody))
+	}
+
+	if result != nil && len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, result); err != nil {
+			return "", false, fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+
+	return resp.Header.Get("ETag"), false, nil
+}
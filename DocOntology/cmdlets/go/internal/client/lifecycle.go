@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Action identifies a schema lifecycle transition.
+type Action string
+
+const (
+	ActionSubmit    Action = "submit"
+	ActionRelease   Action = "release"
+	ActionDeprecate Action = "deprecate"
+	ActionRecover   Action = "recover"
+	ActionReject    Action = "reject"
+)
+
+// transitions defines the legal schema lifecycle state machine: which
+// actions are valid from a given status, and what status they produce.
+var transitions = map[Status]map[Action]Status{
+	StatusDraft: {
+		ActionSubmit:  StatusDraft,
+		ActionRelease: StatusActive,
+		ActionReject:  StatusRejected,
+	},
+	StatusActive: {
+		ActionDeprecate: StatusDeprecated,
+	},
+	StatusDeprecated: {
+		ActionRecover: StatusActive,
+	},
+}
+
+// ValidateTransition checks whether action is legal from the given status,
+// returning the resulting status on success or an error listing the allowed
+// next actions otherwise.
+func ValidateTransition(from Status, action Action) (Status, error) {
+	allowed, ok := transitions[from]
+	if !ok {
+		return "", fmt.Errorf("no transitions are defined from status %q", from)
+	}
+	to, ok := allowed[action]
+	if !ok {
+		return "", fmt.Errorf("action %q is not legal from status %q (allowed: %s)", action, from, allowedActions(allowed))
+	}
+	return to, nil
+}
+
+func allowedActions(allowed map[Action]Status) string {
+	if len(allowed) == 0 {
+		return "none"
+	}
+	actions := make([]string, 0, len(allowed))
+	for a := range allowed {
+		actions = append(actions, string(a))
+	}
+	s := actions[0]
+	for _, a := range actions[1:] {
+		s += ", " + a
+	}
+	return s
+}
+
+// PerformAction transitions a schema version through the lifecycle state
+// machine defined by ValidateTransition, recording req.Message as the audit
+// message for the transition.
+func (c *Client) PerformAction(ctx context.Context, publicVersionID string, req ActionRequest) (*SchemaWithRelations, error) {
+	var result SchemaWithRelations
+	path := "/admin/schemas/versions/" + url.PathEscape(publicVersionID) + "/actions"
+	if err := c.doRequest(ctx, http.MethodPost, path, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetSchemaHistory lists the recorded lifecycle transitions for a schema,
+// oldest first.
+func (c *Client) GetSchemaHistory(ctx context.Context, publicID string) ([]SchemaVersionEvent, error) {
+	var result []SchemaVersionEvent
+	path := "/admin/schemas/" + url.PathEscape(publicID) + "/history"
+	if err := c.doRequest(ctx, http.MethodGet, path, nil, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
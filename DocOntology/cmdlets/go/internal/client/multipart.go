@@ -0,0 +1,136 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+)
+
+// ProgressFunc reports upload progress as a multipart request body is
+// streamed to the server. bytesSent is cumulative across the whole body
+// (multipart envelope included); totalBytes is the pre-computed
+// Content-Length. It may be called from a goroutine other than the one that
+// initiated the request.
+type ProgressFunc func(bytesSent, totalBytes int64)
+
+// multipartField is a plain form field written before or after the file
+// part of a streamed multipart body.
+type multipartField struct {
+	name  string
+	value string
+}
+
+// newMultipartUpload builds a streamed multipart/form-data body for
+// filePath under fileField, with before/after written as plain fields
+// ahead of and behind the file part respectively. Unlike building the body
+// in a bytes.Buffer, the file's contents are never held in memory: the
+// multipart envelope (boundaries, headers, field values) is pre-rendered so
+// its exact size is known, then an io.Pipe streams envelope-prefix, file
+// bytes, and envelope-suffix to the returned reader as the HTTP transport
+// reads from it. The returned contentLength is the exact total, so callers
+// can set it as Content-Length and let the server enforce size limits
+// up front rather than discovering them via chunked transfer.
+//
+// The returned ReadCloser's Close tears the pipe down; if ctx is done
+// before the file finishes streaming, the background goroutine notices
+// between reads, closes the pipe with ctx.Err(), and exits without leaking.
+func newMultipartUpload(ctx context.Context, filePath, fileField string, before, after []multipartField, progress ProgressFunc) (body io.ReadCloser, contentType string, contentLength int64, err error) {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return nil, "", 0, fmt.Errorf("failed to stat file: %w", err)
+	}
+
+	var envelope bytes.Buffer
+	mw := multipart.NewWriter(&envelope)
+
+	for _, f := range before {
+		if err := mw.WriteField(f.name, f.value); err != nil {
+			return nil, "", 0, fmt.Errorf("failed to write %s: %w", f.name, err)
+		}
+	}
+
+	if _, err := mw.CreateFormFile(fileField, filepath.Base(filePath)); err != nil {
+		return nil, "", 0, fmt.Errorf("failed to create form file: %w", err)
+	}
+	prefix := append([]byte(nil), envelope.Bytes()...)
+	envelope.Reset()
+
+	for _, f := range after {
+		if err := mw.WriteField(f.name, f.value); err != nil {
+			return nil, "", 0, fmt.Errorf("failed to write %s: %w", f.name, err)
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, "", 0, fmt.Errorf("failed to close writer: %w", err)
+	}
+	suffix := envelope.Bytes()
+
+	total := int64(len(prefix)) + info.Size() + int64(len(suffix))
+
+	pr, pw := io.Pipe()
+	go streamMultipartUpload(ctx, pw, filePath, prefix, suffix, total, progress)
+
+	return pr, mw.FormDataContentType(), total, nil
+}
+
+// streamMultipartUpload drives the write side of the pipe created by
+// newMultipartUpload: prefix, then the file's bytes (re-opened here so each
+// retry attempt streams a fresh read from disk), then suffix. It always
+// closes pw, with whatever error (if any) caused it to stop early.
+func streamMultipartUpload(ctx context.Context, pw *io.PipeWriter, filePath string, prefix, suffix []byte, total int64, progress ProgressFunc) {
+	var sent int64
+	write := func(p []byte) error {
+		if _, err := pw.Write(p); err != nil {
+			return err
+		}
+		sent += int64(len(p))
+		if progress != nil {
+			progress(sent, total)
+		}
+		return nil
+	}
+
+	if err := write(prefix); err != nil {
+		pw.CloseWithError(err)
+		return
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		pw.CloseWithError(fmt.Errorf("failed to open file: %w", err))
+		return
+	}
+	defer file.Close()
+
+	buf := make([]byte, 32*1024)
+	for {
+		if err := ctx.Err(); err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+		n, readErr := file.Read(buf)
+		if n > 0 {
+			if err := write(buf[:n]); err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			pw.CloseWithError(fmt.Errorf("failed to read file: %w", readErr))
+			return
+		}
+	}
+
+	if err := write(suffix); err != nil {
+		pw.CloseWithError(err)
+		return
+	}
+	pw.Close()
+}
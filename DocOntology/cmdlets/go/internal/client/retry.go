@@ -0,0 +1,155 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// retryBaseDelay and retryMaxDelay bound the exponential backoff applied
+// between retry attempts when the server doesn't send a Retry-After header.
+const (
+	retryBaseDelay = 200 * time.Millisecond
+	retryMaxDelay  = 10 * time.Second
+)
+
+// Option configures a Client beyond what config.Config provides, for
+// overriding retry/rate-limit behavior on a single call site.
+type Option func(*Client)
+
+// WithMaxRetries overrides how many times a request is retried on a
+// transient status (429, 502, 503, 504) or network error.
+func WithMaxRetries(n int) Option {
+	return func(c *Client) { c.maxRetries = n }
+}
+
+// WithRateLimit overrides the requests-per-second cap shared across every
+// call on this Client. A non-positive value disables rate limiting.
+func WithRateLimit(rps float64) Option {
+	return func(c *Client) { c.limiter = newLimiter(rps) }
+}
+
+func newLimiter(rps float64) *rate.Limiter {
+	if rps <= 0 {
+		return nil
+	}
+	return rate.NewLimiter(rate.Limit(rps), 1)
+}
+
+// doWithRetry runs buildReq and executes the resulting request, retrying on
+// 429/502/503/504 responses and transient network errors with exponential
+// backoff and jitter (honoring a Retry-After header when the server sends
+// one) up to c.maxRetries times. buildReq is called fresh on every attempt
+// since a request's body reader can't be rewound after being read. ctx is
+// threaded into every attempt's request and also bounds the rate limiter's
+// wait and the sleep between retries, so a cancelled or expired context
+// aborts promptly instead of waiting out the full backoff.
+func (c *Client) doWithRetry(ctx context.Context, buildReq func(ctx context.Context) (*http.Request, error)) (status int, respBody []byte, err error) {
+	for attempt := 0; ; attempt++ {
+		if c.limiter != nil {
+			if err := c.limiter.Wait(ctx); err != nil {
+				return 0, nil, err
+			}
+		}
+
+		req, err := buildReq(ctx)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		resp, doErr := c.httpClient.Do(req)
+		if doErr != nil {
+			if ctx.Err() != nil {
+				return 0, nil, ctx.Err()
+			}
+			if attempt < c.maxRetries && isRetryableNetErr(doErr) {
+				if err := sleepCtx(ctx, backoff(attempt, nil)); err != nil {
+					return 0, nil, err
+				}
+				continue
+			}
+			return 0, nil, doErr
+		}
+
+		body, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if readErr != nil {
+			return 0, nil, readErr
+		}
+
+		if attempt < c.maxRetries && isRetryableStatus(resp.StatusCode) {
+			if err := sleepCtx(ctx, backoff(attempt, resp)); err != nil {
+				return 0, nil, err
+			}
+			continue
+		}
+
+		return resp.StatusCode, body, nil
+	}
+}
+
+// sleepCtx sleeps for d, returning early with ctx.Err() if ctx is cancelled
+// or expires first.
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func isRetryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+func isRetryableNetErr(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// backoff computes how long to wait before the next attempt: the
+// Retry-After header if the server sent one, otherwise exponential backoff
+// with jitter, capped at retryMaxDelay.
+func backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if d, ok := retryAfter(resp); ok {
+			return d
+		}
+	}
+	delay := retryBaseDelay * time.Duration(int64(1)<<uint(attempt))
+	if delay > retryMaxDelay {
+		delay = retryMaxDelay
+	}
+	return delay + time.Duration(rand.Int63n(int64(retryBaseDelay)))
+}
+
+// retryAfter parses a Retry-After header, which the HTTP spec allows to be
+// either a number of seconds or an HTTP-date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t), true
+	}
+	return 0, false
+}
@@ -0,0 +1,129 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// SchemaSummaryHeader is the header CreateSchema/UpdateSchema attach with
+// the schema content's SHA-256 summary, and that VerifySchema expects the
+// server to echo back unchanged on GET.
+const SchemaSummaryHeader = "X-Schema-Summary"
+
+// CanonicalizeAndHash decodes data as JSON and re-encodes it canonically —
+// object keys sorted (encoding/json already sorts map[string]interface{}
+// keys on Marshal, at every nesting level) and numbers preserved exactly via
+// json.Number rather than round-tripped through float64 — then returns the
+// hex SHA-256 digest of the canonical form. Two JSON documents that encode
+// the same value with different key order or whitespace hash identically.
+func CanonicalizeAndHash(data []byte) (string, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return "", fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	canonical, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("failed to canonicalize JSON: %w", err)
+	}
+
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// schemaSummary computes the content summary sent as SchemaSummaryHeader.
+func schemaSummary(content map[string]interface{}) (string, error) {
+	data, err := json.Marshal(content)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal schema content: %w", err)
+	}
+	return CanonicalizeAndHash(data)
+}
+
+// contentSummaryHeader builds the X-Schema-Summary header for a write, or
+// nil if content is unset (e.g. an UpdateSchemaRequest that doesn't touch
+// Content).
+func contentSummaryHeader(content map[string]interface{}) (map[string]string, error) {
+	if content == nil {
+		return nil, nil
+	}
+	summary, err := schemaSummary(content)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{SchemaSummaryHeader: summary}, nil
+}
+
+// GetSchemaSummary fetches the schema identified by id (as accepted by
+// GetSchema) and returns the SHA-256 summary computed locally from its
+// content, so callers don't have to fetch-then-hash themselves.
+func (c *Client) GetSchemaSummary(ctx context.Context, id string) (string, error) {
+	schema, err := c.GetSchema(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	return schemaSummary(schema.Content)
+}
+
+// VerifySchema fetches the schema identified by id, recomputes its content
+// summary, and compares it against the X-Schema-Summary header the server
+// returns alongside it (the same header CreateSchema/UpdateSchema sent when
+// the content was last written). A mismatch means the content and the
+// summary the server has on record disagree; a missing header means the
+// server doesn't echo one back, so nothing can be verified.
+func (c *Client) VerifySchema(ctx context.Context, id string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/admin/schemas/"+url.PathEscape(id), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(respBody, &errResp); err == nil && errResp.Error != "" {
+			return &APIError{StatusCode: resp.StatusCode, Message: errResp.Error}
+		}
+		return &APIError{StatusCode: resp.StatusCode, Message: string(respBody)}
+	}
+
+	var schema SchemaWithRelations
+	if err := json.Unmarshal(respBody, &schema); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	want := resp.Header.Get(SchemaSummaryHeader)
+	if want == "" {
+		return fmt.Errorf("server did not return an %s header for %s", SchemaSummaryHeader, id)
+	}
+
+	got, err := schemaSummary(schema.Content)
+	if err != nil {
+		return err
+	}
+	if got != want {
+		return fmt.Errorf("schema summary mismatch for %s: server reports %s, content hashes to %s", id, want, got)
+	}
+	return nil
+}
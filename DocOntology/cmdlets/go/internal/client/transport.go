@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/config"
+)
+
+// buildTransport returns nil (letting http.Client fall back to
+// http.DefaultTransport) unless cfg asks for a Unix domain socket and/or
+// mutual TLS, in which case it returns a *http.Transport configured
+// accordingly. Both can be set together, e.g. a registry reachable only
+// over a local sidecar socket that still terminates mTLS.
+func buildTransport(cfg *config.Config) (*http.Transport, error) {
+	if cfg.APISocketPath == "" && cfg.ClientCertFile == "" && cfg.CACertFile == "" && !cfg.InsecureSkipVerify {
+		return nil, nil
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if cfg.APISocketPath != "" {
+		// baseURL's host:port is never actually dialed: every connection
+		// goes to the socket instead, whatever host the request URL names.
+		dialer := &net.Dialer{}
+		socketPath := cfg.APISocketPath
+		transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return dialer.DialContext(ctx, "unix", socketPath)
+		}
+	}
+
+	if cfg.ClientCertFile != "" || cfg.CACertFile != "" || cfg.InsecureSkipVerify {
+		tlsConfig, err := buildTLSConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	return transport, nil
+}
+
+// buildTLSConfig assembles a tls.Config from cfg's client certificate/key
+// and CA pool, for mutual TLS against an on-prem Schema Registry.
+func buildTLSConfig(cfg *config.Config) (*tls.Config, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: cfg.InsecureSkipVerify}
+
+	if cfg.ClientCertFile != "" {
+		if cfg.ClientKeyFile == "" {
+			return nil, fmt.Errorf("client certificate set without a client key (ClientCertFile requires ClientKeyFile)")
+		}
+		cert, err := tls.LoadX509KeyPair(cfg.ClientCertFile, cfg.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if cfg.CACertFile != "" {
+		pem, err := os.ReadFile(cfg.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", cfg.CACertFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	return tlsConfig, nil
+}
@@ -9,6 +9,7 @@ const (
 	StatusDraft      Status = "draft"
 	StatusActive     Status = "active"
 	StatusDeprecated Status = "deprecated"
+	StatusRejected   Status = "rejected"
 )
 
 // Visibility represents the schema visibility level
@@ -163,6 +164,23 @@ type HealthResponse struct {
 	Timestamp time.Time `json:"timestamp"`
 }
 
+// ActionRequest is the request body for a schema lifecycle transition.
+type ActionRequest struct {
+	Action  Action `json:"action"`
+	Message string `json:"message,omitempty"`
+}
+
+// SchemaVersionEvent represents a single lifecycle transition recorded
+// against a schema version.
+type SchemaVersionEvent struct {
+	Action    Action    `json:"action"`
+	FromState Status    `json:"fromState"`
+	ToState   Status    `json:"toState"`
+	Message   string    `json:"message,omitempty"`
+	Actor     string    `json:"actor,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
 // ErrorResponse represents an API error
 type ErrorResponse struct {
 	Error   string                 `json:"error"`
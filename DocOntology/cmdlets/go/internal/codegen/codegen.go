@@ -0,0 +1,53 @@
+// Package codegen generates strongly-typed source bindings from a
+// registered schema's JSON Schema content, one CodeGenerator per target
+// language, behind `schemactl schemas codegen --lang go|ts|python`.
+package codegen
+
+import "fmt"
+
+// Language identifies a codegen target, matching the --lang flag value.
+type Language string
+
+const (
+	LangGo     Language = "go"
+	LangTS     Language = "ts"
+	LangPython Language = "python"
+)
+
+// Schema is the subset of a registered schema codegen needs: its JSON
+// Schema content plus the identifying fields used to derive file and type
+// names.
+type Schema struct {
+	PublicID string
+	Name     string
+	Content  map[string]interface{}
+}
+
+// CodeGenerator turns a single schema's JSON Schema content into source
+// code for one target language. New languages are added by implementing
+// this interface and registering it in ForLanguage.
+type CodeGenerator interface {
+	// Language is the generator's --lang identifier.
+	Language() Language
+	// FileExtension is the file extension (without the dot) generated
+	// files use, e.g. "go", "ts", "py".
+	FileExtension() string
+	// Generate returns the generated source for schema, with its root type
+	// named typeName (already a valid identifier) and, for languages that
+	// have the concept, placed in packageName.
+	Generate(schema Schema, typeName, packageName string) ([]byte, error)
+}
+
+// ForLanguage returns the CodeGenerator registered for lang.
+func ForLanguage(lang Language) (CodeGenerator, error) {
+	switch lang {
+	case LangGo:
+		return &goGenerator{}, nil
+	case LangTS:
+		return &tsGenerator{}, nil
+	case LangPython:
+		return &pythonGenerator{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported --lang %q (expected go, ts, or python)", lang)
+	}
+}
@@ -0,0 +1,221 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+)
+
+// goGenerator implements CodeGenerator for --lang go: one struct per
+// object schema (exported fields, `json:"name,omitempty"` tags, pointers
+// for non-required fields), a typed string constant block per enum, and an
+// interface-plus-variants-plus-UnmarshalJSON box per oneOf/anyOf union.
+type goGenerator struct{}
+
+func (g *goGenerator) Language() Language    { return LangGo }
+func (g *goGenerator) FileExtension() string { return "go" }
+
+func (g *goGenerator) Generate(schema Schema, typeName, packageName string) ([]byte, error) {
+	if packageName == "" {
+		packageName = "models"
+	}
+	b := &goBuilder{packageName: packageName, definitions: definitionsOf(schema.Content), emitted: map[string]bool{}, imports: map[string]bool{}}
+	if _, err := b.typeFor(schema.Content, exportIdent(typeName)); err != nil {
+		return nil, fmt.Errorf("%s: %w", schema.PublicID, err)
+	}
+
+	src := b.render()
+	formatted, err := format.Source(src)
+	if err != nil {
+		// Return the unformatted source alongside the error so a caller can
+		// still inspect what codegen produced.
+		return src, fmt.Errorf("generated Go source for %s failed to gofmt: %w", schema.PublicID, err)
+	}
+	return formatted, nil
+}
+
+type goBuilder struct {
+	packageName string
+	definitions map[string]interface{}
+	emitted     map[string]bool
+	decls       []string
+	imports     map[string]bool
+}
+
+// typeFor returns the Go type expression for schema, emitting any named
+// struct/enum/union declaration it requires as a side effect. name is the
+// Go type name to use if schema turns out to need one of its own; it's
+// ignored for schemas that resolve to a plain scalar/slice type.
+func (b *goBuilder) typeFor(schema map[string]interface{}, name string) (string, error) {
+	if ref, ok := schema["$ref"].(string); ok {
+		return b.resolveRef(ref)
+	}
+	if enumVals, ok := schema["enum"].([]interface{}); ok {
+		return b.emitEnum(name, enumVals)
+	}
+	if variants := unionVariants(schema); variants != nil {
+		return b.emitUnion(name, variants)
+	}
+
+	switch schemaType(schema) {
+	case "object":
+		return b.emitStruct(name, schema)
+	case "array":
+		items, _ := schema["items"].(map[string]interface{})
+		elemType, err := b.typeFor(items, singularize(name))
+		if err != nil {
+			return "", err
+		}
+		return "[]" + elemType, nil
+	case "string":
+		if schema["format"] == "date-time" {
+			b.imports["time"] = true
+			return "time.Time", nil
+		}
+		return "string", nil
+	case "integer":
+		return "int", nil
+	case "number":
+		return "float64", nil
+	case "boolean":
+		return "bool", nil
+	default:
+		return "interface{}", nil
+	}
+}
+
+func (b *goBuilder) resolveRef(ref string) (string, error) {
+	name, err := refName(ref)
+	if err != nil {
+		return "", err
+	}
+	typeName := exportIdent(name)
+	if b.emitted[typeName] {
+		return typeName, nil
+	}
+	def, ok := b.definitions[name]
+	if !ok {
+		return "", fmt.Errorf("$ref %q: no definition named %q", ref, name)
+	}
+	defSchema, _ := def.(map[string]interface{})
+	return b.typeFor(defSchema, typeName)
+}
+
+func (b *goBuilder) emitStruct(name string, schema map[string]interface{}) (string, error) {
+	typeName := exportIdent(name)
+	if b.emitted[typeName] {
+		return typeName, nil
+	}
+	b.emitted[typeName] = true
+
+	required := requiredSet(schema)
+	keys, props := sortedProperties(schema)
+
+	var fields []string
+	for _, key := range keys {
+		propSchema, _ := props[key].(map[string]interface{})
+		fieldName := exportIdent(key)
+		goType, err := b.typeFor(propSchema, typeName+fieldName)
+		if err != nil {
+			return "", fmt.Errorf("%s.%s: %w", typeName, key, err)
+		}
+		tag := key
+		if !required[key] {
+			tag += ",omitempty"
+			if !strings.HasPrefix(goType, "[]") && !strings.HasPrefix(goType, "map[") {
+				goType = "*" + goType
+			}
+		}
+		fields = append(fields, fmt.Sprintf("\t%s %s `json:\"%s\"`", fieldName, goType, tag))
+	}
+
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "type %s struct {\n%s\n}\n", typeName, strings.Join(fields, "\n"))
+	b.decls = append(b.decls, buf.String())
+	return typeName, nil
+}
+
+func (b *goBuilder) emitEnum(name string, values []interface{}) (string, error) {
+	typeName := exportIdent(name)
+	if b.emitted[typeName] {
+		return typeName, nil
+	}
+	b.emitted[typeName] = true
+
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "type %s string\n\nconst (\n", typeName)
+	for _, v := range values {
+		s, ok := v.(string)
+		if !ok {
+			continue
+		}
+		fmt.Fprintf(&buf, "\t%s%s %s = %q\n", typeName, exportIdent(s), typeName, s)
+	}
+	buf.WriteString(")\n")
+	b.decls = append(b.decls, buf.String())
+	return typeName, nil
+}
+
+// emitUnion emits an interface satisfied by each oneOf/anyOf variant, and a
+// "Box" wrapper whose UnmarshalJSON tries each variant in declaration order
+// and keeps the first that decodes without error — Go has no direct
+// equivalent of a JSON union type, so the box is the idiomatic workaround.
+func (b *goBuilder) emitUnion(name string, variants []map[string]interface{}) (string, error) {
+	typeName := exportIdent(name)
+	if b.emitted[typeName] {
+		return typeName + "Box", nil
+	}
+	b.emitted[typeName] = true
+
+	var variantNames []string
+	for i, v := range variants {
+		variantName, err := b.typeFor(v, fmt.Sprintf("%sVariant%d", typeName, i+1))
+		if err != nil {
+			return "", err
+		}
+		variantNames = append(variantNames, variantName)
+	}
+
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "// %s is satisfied by exactly one of: %s.\ntype %s interface {\n\tis%s()\n}\n\n", typeName, strings.Join(variantNames, ", "), typeName, typeName)
+	for _, vn := range variantNames {
+		fmt.Fprintf(&buf, "func (%s) is%s() {}\n", vn, typeName)
+	}
+	buf.WriteString("\n")
+	fmt.Fprintf(&buf, "// %sBox unmarshals into whichever variant of %s decodes\n// without error first, tried in declaration order.\ntype %sBox struct {\n\tValue %s\n}\n\n", typeName, typeName, typeName, typeName)
+	fmt.Fprintf(&buf, "func (x *%sBox) UnmarshalJSON(data []byte) error {\n", typeName)
+	for _, vn := range variantNames {
+		v := strings.ToLower(vn)
+		fmt.Fprintf(&buf, "\tvar %s %s\n\tif err := json.Unmarshal(data, &%s); err == nil {\n\t\tx.Value = %s\n\t\treturn nil\n\t}\n", v, vn, v, v)
+	}
+	fmt.Fprintf(&buf, "\treturn fmt.Errorf(\"no variant of %s matched\")\n}\n", typeName)
+
+	b.decls = append(b.decls, buf.String())
+	b.imports["encoding/json"] = true
+	b.imports["fmt"] = true
+	return typeName + "Box", nil
+}
+
+func (b *goBuilder) render() []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "package %s\n\n", b.packageName)
+	if len(b.imports) > 0 {
+		names := make([]string, 0, len(b.imports))
+		for imp := range b.imports {
+			names = append(names, imp)
+		}
+		sort.Strings(names)
+		buf.WriteString("import (\n")
+		for _, imp := range names {
+			fmt.Fprintf(&buf, "\t%q\n", imp)
+		}
+		buf.WriteString(")\n\n")
+	}
+	for _, decl := range b.decls {
+		buf.WriteString(decl)
+		buf.WriteString("\n")
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,195 @@
+package codegen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// pythonGenerator implements CodeGenerator for --lang python: one pydantic
+// BaseModel per object schema, a str Enum per enum, and a typing.Union per
+// oneOf/anyOf — pydantic validates which variant matched at parse time, so
+// no custom decode logic is needed the way Go's union box requires.
+type pythonGenerator struct{}
+
+func (g *pythonGenerator) Language() Language    { return LangPython }
+func (g *pythonGenerator) FileExtension() string { return "py" }
+
+func (g *pythonGenerator) Generate(schema Schema, typeName, packageName string) ([]byte, error) {
+	b := &pythonBuilder{
+		definitions: definitionsOf(schema.Content),
+		emitted:     map[string]bool{},
+		imports:     map[string]bool{"from pydantic import BaseModel": true},
+	}
+	if _, err := b.typeFor(schema.Content, exportIdent(typeName)); err != nil {
+		return nil, fmt.Errorf("%s: %w", schema.PublicID, err)
+	}
+	return []byte(b.render()), nil
+}
+
+type pythonBuilder struct {
+	definitions map[string]interface{}
+	emitted     map[string]bool
+	decls       []string
+	imports     map[string]bool
+}
+
+func (b *pythonBuilder) typeFor(schema map[string]interface{}, name string) (string, error) {
+	if ref, ok := schema["$ref"].(string); ok {
+		return b.resolveRef(ref)
+	}
+	if enumVals, ok := schema["enum"].([]interface{}); ok {
+		return b.emitEnum(name, enumVals)
+	}
+	if variants := unionVariants(schema); variants != nil {
+		return b.emitUnion(name, variants)
+	}
+
+	switch schemaType(schema) {
+	case "object":
+		return b.emitModel(name, schema)
+	case "array":
+		items, _ := schema["items"].(map[string]interface{})
+		elemType, err := b.typeFor(items, singularize(name))
+		if err != nil {
+			return "", err
+		}
+		b.imports["from typing import List"] = true
+		return "List[" + elemType + "]", nil
+	case "string":
+		if schema["format"] == "date-time" {
+			b.imports["from datetime import datetime"] = true
+			return "datetime", nil
+		}
+		return "str", nil
+	case "integer":
+		return "int", nil
+	case "number":
+		return "float", nil
+	case "boolean":
+		return "bool", nil
+	default:
+		b.imports["from typing import Any"] = true
+		return "Any", nil
+	}
+}
+
+func (b *pythonBuilder) resolveRef(ref string) (string, error) {
+	name, err := refName(ref)
+	if err != nil {
+		return "", err
+	}
+	typeName := exportIdent(name)
+	if b.emitted[typeName] {
+		return typeName, nil
+	}
+	def, ok := b.definitions[name]
+	if !ok {
+		return "", fmt.Errorf("$ref %q: no definition named %q", ref, name)
+	}
+	defSchema, _ := def.(map[string]interface{})
+	return b.typeFor(defSchema, typeName)
+}
+
+func (b *pythonBuilder) emitModel(name string, schema map[string]interface{}) (string, error) {
+	typeName := exportIdent(name)
+	if b.emitted[typeName] {
+		return typeName, nil
+	}
+	b.emitted[typeName] = true
+
+	required := requiredSet(schema)
+	keys, props := sortedProperties(schema)
+
+	var fields []string
+	for _, key := range keys {
+		propSchema, _ := props[key].(map[string]interface{})
+		fieldName := snakeIdent(key)
+		pyType, err := b.typeFor(propSchema, typeName+exportIdent(key))
+		if err != nil {
+			return "", fmt.Errorf("%s.%s: %w", typeName, key, err)
+		}
+
+		needsAlias := fieldName != key
+		if needsAlias {
+			b.imports["from pydantic import Field"] = true
+		}
+
+		switch {
+		case required[key] && !needsAlias:
+			fields = append(fields, fmt.Sprintf("    %s: %s", fieldName, pyType))
+		case required[key]:
+			fields = append(fields, fmt.Sprintf("    %s: %s = Field(alias=%q)", fieldName, pyType, key))
+		case !needsAlias:
+			b.imports["from typing import Optional"] = true
+			fields = append(fields, fmt.Sprintf("    %s: Optional[%s] = None", fieldName, pyType))
+		default:
+			b.imports["from typing import Optional"] = true
+			fields = append(fields, fmt.Sprintf("    %s: Optional[%s] = Field(default=None, alias=%q)", fieldName, pyType, key))
+		}
+	}
+	if len(fields) == 0 {
+		fields = []string{"    pass"}
+	}
+
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "class %s(BaseModel):\n%s\n", typeName, strings.Join(fields, "\n"))
+	b.decls = append(b.decls, buf.String())
+	return typeName, nil
+}
+
+func (b *pythonBuilder) emitEnum(name string, values []interface{}) (string, error) {
+	typeName := exportIdent(name)
+	if b.emitted[typeName] {
+		return typeName, nil
+	}
+	b.emitted[typeName] = true
+	b.imports["from enum import Enum"] = true
+
+	var members []string
+	for _, v := range values {
+		s, ok := v.(string)
+		if !ok {
+			continue
+		}
+		members = append(members, fmt.Sprintf("    %s = %q", strings.ToUpper(snakeIdent(s)), s))
+	}
+	b.decls = append(b.decls, fmt.Sprintf("class %s(str, Enum):\n%s\n", typeName, strings.Join(members, "\n")))
+	return typeName, nil
+}
+
+func (b *pythonBuilder) emitUnion(name string, variants []map[string]interface{}) (string, error) {
+	typeName := exportIdent(name)
+	if b.emitted[typeName] {
+		return typeName, nil
+	}
+	b.emitted[typeName] = true
+	b.imports["from typing import Union"] = true
+
+	var variantNames []string
+	for i, v := range variants {
+		variantName, err := b.typeFor(v, fmt.Sprintf("%sVariant%d", typeName, i+1))
+		if err != nil {
+			return "", err
+		}
+		variantNames = append(variantNames, variantName)
+	}
+	b.decls = append(b.decls, fmt.Sprintf("%s = Union[%s]\n", typeName, strings.Join(variantNames, ", ")))
+	return typeName, nil
+}
+
+func (b *pythonBuilder) render() string {
+	var buf strings.Builder
+	imports := make([]string, 0, len(b.imports))
+	for imp := range b.imports {
+		imports = append(imports, imp)
+	}
+	sort.Strings(imports)
+	for _, imp := range imports {
+		buf.WriteString(imp)
+		buf.WriteString("\n")
+	}
+	buf.WriteString("\n\n")
+	buf.WriteString(strings.Join(b.decls, "\n"))
+	return buf.String()
+}
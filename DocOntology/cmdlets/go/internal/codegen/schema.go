@@ -0,0 +1,156 @@
+package codegen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// schemaType returns the effective JSON Schema "type" of schema, inferring
+// object/array from the presence of properties/items when "type" itself is
+// absent (common in hand-written schemas).
+func schemaType(schema map[string]interface{}) string {
+	if t, ok := schema["type"].(string); ok {
+		return t
+	}
+	if _, ok := schema["properties"]; ok {
+		return "object"
+	}
+	if _, ok := schema["items"]; ok {
+		return "array"
+	}
+	return ""
+}
+
+// unionVariants returns the oneOf/anyOf branches of schema as
+// map[string]interface{}, or nil if it has neither.
+func unionVariants(schema map[string]interface{}) []map[string]interface{} {
+	for _, key := range []string{"oneOf", "anyOf"} {
+		raw, ok := schema[key].([]interface{})
+		if !ok {
+			continue
+		}
+		var variants []map[string]interface{}
+		for _, v := range raw {
+			if m, ok := v.(map[string]interface{}); ok {
+				variants = append(variants, m)
+			}
+		}
+		if len(variants) > 0 {
+			return variants
+		}
+	}
+	return nil
+}
+
+// requiredSet returns schema's "required" array as a lookup set.
+func requiredSet(schema map[string]interface{}) map[string]bool {
+	required := map[string]bool{}
+	if req, ok := schema["required"].([]interface{}); ok {
+		for _, r := range req {
+			if s, ok := r.(string); ok {
+				required[s] = true
+			}
+		}
+	}
+	return required
+}
+
+// sortedProperties returns schema's "properties" keys in sorted order
+// (alongside the map itself), so generated output is deterministic.
+func sortedProperties(schema map[string]interface{}) ([]string, map[string]interface{}) {
+	props, _ := schema["properties"].(map[string]interface{})
+	keys := make([]string, 0, len(props))
+	for k := range props {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys, props
+}
+
+// definitionsOf returns schema's "definitions" or "$defs" map, whichever is
+// set (JSON Schema draft-04 and draft 2019-09+ spellings, respectively).
+func definitionsOf(schema map[string]interface{}) map[string]interface{} {
+	if defs, ok := schema["definitions"].(map[string]interface{}); ok {
+		return defs
+	}
+	defs, _ := schema["$defs"].(map[string]interface{})
+	return defs
+}
+
+// refName extracts the trailing component of a local JSON Pointer $ref,
+// e.g. "#/definitions/Address" -> "Address". Non-local refs (to another
+// document) aren't supported: a generated file can only reference types it
+// also generates.
+func refName(ref string) (string, error) {
+	if !strings.HasPrefix(ref, "#/") {
+		return "", fmt.Errorf("unsupported $ref %q (only local #/definitions/Name and #/$defs/Name refs are supported)", ref)
+	}
+	parts := strings.Split(ref, "/")
+	return parts[len(parts)-1], nil
+}
+
+// exportIdent turns an arbitrary JSON Schema property/definition name into
+// an exported, PascalCase identifier valid in Go, TypeScript, and Python
+// class names alike.
+func exportIdent(s string) string {
+	var b strings.Builder
+	upperNext := true
+	for _, r := range s {
+		switch {
+		case r == '_' || r == '-' || r == ' ' || r == '.':
+			upperNext = true
+		case upperNext:
+			b.WriteRune(unicode.ToUpper(r))
+			upperNext = false
+		default:
+			b.WriteRune(r)
+		}
+	}
+	out := b.String()
+	if out == "" {
+		return "Field"
+	}
+	if unicode.IsDigit(rune(out[0])) {
+		out = "X" + out
+	}
+	return out
+}
+
+// snakeIdent turns an arbitrary JSON Schema property name into a
+// snake_case identifier, for Python field names.
+func snakeIdent(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		switch {
+		case r == '-' || r == ' ' || r == '.':
+			b.WriteRune('_')
+		case unicode.IsUpper(r):
+			if i > 0 {
+				b.WriteRune('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+		default:
+			b.WriteRune(r)
+		}
+	}
+	out := b.String()
+	if out == "" {
+		return "field"
+	}
+	if unicode.IsDigit(rune(out[0])) {
+		out = "x" + out
+	}
+	return out
+}
+
+// singularize derives a name for an array's element type from the array
+// field's own name, e.g. "tags" -> "tag". It's a best-effort heuristic, not
+// true English singularization.
+func singularize(name string) string {
+	if strings.HasSuffix(name, "s") && len(name) > 1 {
+		return strings.TrimSuffix(name, "s")
+	}
+	return name + "Item"
+}
@@ -0,0 +1,151 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// tsGenerator implements CodeGenerator for --lang ts: one `export
+// interface` per object schema, a string-literal union type per enum, and
+// a plain union type per oneOf/anyOf (TypeScript's structural typing needs
+// no discriminator wrapper the way Go's union box does).
+type tsGenerator struct{}
+
+func (g *tsGenerator) Language() Language    { return LangTS }
+func (g *tsGenerator) FileExtension() string { return "ts" }
+
+func (g *tsGenerator) Generate(schema Schema, typeName, packageName string) ([]byte, error) {
+	b := &tsBuilder{definitions: definitionsOf(schema.Content), emitted: map[string]bool{}}
+	if _, err := b.typeFor(schema.Content, exportIdent(typeName)); err != nil {
+		return nil, fmt.Errorf("%s: %w", schema.PublicID, err)
+	}
+	return []byte(b.render()), nil
+}
+
+type tsBuilder struct {
+	definitions map[string]interface{}
+	emitted     map[string]bool
+	decls       []string
+}
+
+func (b *tsBuilder) typeFor(schema map[string]interface{}, name string) (string, error) {
+	if ref, ok := schema["$ref"].(string); ok {
+		return b.resolveRef(ref)
+	}
+	if enumVals, ok := schema["enum"].([]interface{}); ok {
+		return b.emitEnum(name, enumVals)
+	}
+	if variants := unionVariants(schema); variants != nil {
+		return b.emitUnion(name, variants)
+	}
+
+	switch schemaType(schema) {
+	case "object":
+		return b.emitInterface(name, schema)
+	case "array":
+		items, _ := schema["items"].(map[string]interface{})
+		elemType, err := b.typeFor(items, singularize(name))
+		if err != nil {
+			return "", err
+		}
+		return elemType + "[]", nil
+	case "string":
+		// format: date-time is left as the ISO-8601 string the API sends;
+		// callers new Date() it themselves rather than codegen guessing at
+		// a serialization convention.
+		return "string", nil
+	case "integer", "number":
+		return "number", nil
+	case "boolean":
+		return "boolean", nil
+	default:
+		return "unknown", nil
+	}
+}
+
+func (b *tsBuilder) resolveRef(ref string) (string, error) {
+	name, err := refName(ref)
+	if err != nil {
+		return "", err
+	}
+	typeName := exportIdent(name)
+	if b.emitted[typeName] {
+		return typeName, nil
+	}
+	def, ok := b.definitions[name]
+	if !ok {
+		return "", fmt.Errorf("$ref %q: no definition named %q", ref, name)
+	}
+	defSchema, _ := def.(map[string]interface{})
+	return b.typeFor(defSchema, typeName)
+}
+
+func (b *tsBuilder) emitInterface(name string, schema map[string]interface{}) (string, error) {
+	typeName := exportIdent(name)
+	if b.emitted[typeName] {
+		return typeName, nil
+	}
+	b.emitted[typeName] = true
+
+	required := requiredSet(schema)
+	keys, props := sortedProperties(schema)
+
+	var fields []string
+	for _, key := range keys {
+		propSchema, _ := props[key].(map[string]interface{})
+		tsType, err := b.typeFor(propSchema, typeName+exportIdent(key))
+		if err != nil {
+			return "", fmt.Errorf("%s.%s: %w", typeName, key, err)
+		}
+		optional := ""
+		if !required[key] {
+			optional = "?"
+		}
+		fields = append(fields, fmt.Sprintf("  %s%s: %s;", key, optional, tsType))
+	}
+
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "export interface %s {\n%s\n}\n", typeName, strings.Join(fields, "\n"))
+	b.decls = append(b.decls, buf.String())
+	return typeName, nil
+}
+
+func (b *tsBuilder) emitEnum(name string, values []interface{}) (string, error) {
+	typeName := exportIdent(name)
+	if b.emitted[typeName] {
+		return typeName, nil
+	}
+	b.emitted[typeName] = true
+
+	var literals []string
+	for _, v := range values {
+		if s, ok := v.(string); ok {
+			literals = append(literals, fmt.Sprintf("%q", s))
+		}
+	}
+	b.decls = append(b.decls, fmt.Sprintf("export type %s = %s;\n", typeName, strings.Join(literals, " | ")))
+	return typeName, nil
+}
+
+func (b *tsBuilder) emitUnion(name string, variants []map[string]interface{}) (string, error) {
+	typeName := exportIdent(name)
+	if b.emitted[typeName] {
+		return typeName, nil
+	}
+	b.emitted[typeName] = true
+
+	var variantNames []string
+	for i, v := range variants {
+		variantName, err := b.typeFor(v, fmt.Sprintf("%sVariant%d", typeName, i+1))
+		if err != nil {
+			return "", err
+		}
+		variantNames = append(variantNames, variantName)
+	}
+	b.decls = append(b.decls, fmt.Sprintf("export type %s = %s;\n", typeName, strings.Join(variantNames, " | ")))
+	return typeName, nil
+}
+
+func (b *tsBuilder) render() string {
+	return strings.Join(b.decls, "\n")
+}
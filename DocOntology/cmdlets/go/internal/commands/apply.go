@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/apply"
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/manifest"
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var applyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Reconcile doc types, countries, and schemas against a manifest file",
+	Long:  "Read one or more YAML/JSON manifest documents (kind: Schema|DocType|Country) and reconcile the registry to match: create what's missing, update what's drifted, and optionally prune what's unmanaged.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, _ := cmd.Flags().GetString("filename")
+		dryRun, _ := cmd.Flags().GetString("dry-run")
+		prune, _ := cmd.Flags().GetBool("prune")
+		yes, _ := cmd.Flags().GetBool("yes")
+
+		docs, err := manifest.ParsePath(file)
+		if err != nil {
+			return err
+		}
+
+		plan, err := apply.Build(cmd.Context(), GetClient(), docs, apply.Options{Prune: prune})
+		if err != nil {
+			return err
+		}
+
+		if output.JSONOutput {
+			return output.PrintJSON(plan)
+		}
+
+		printPlan(plan)
+
+		if dryRun != "" {
+			return nil
+		}
+		if !plan.HasChanges() {
+			output.PrintSuccess("Nothing to apply")
+			return nil
+		}
+		if !yes && !output.Confirm("Apply the plan above?") {
+			return fmt.Errorf("apply cancelled")
+		}
+		if err := plan.Execute(cmd.Context(), GetClient()); err != nil {
+			return err
+		}
+		output.PrintSuccess("Apply complete")
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(applyCmd)
+
+	applyCmd.Flags().StringP("filename", "f", "", "Manifest file or directory to apply (required)")
+	applyCmd.Flags().String("dry-run", "", "If set to \"client\", print the plan without contacting the server to apply it")
+	applyCmd.Flags().Bool("prune", false, "Delete managed doc types/countries absent from the manifest set")
+	applyCmd.Flags().Bool("yes", false, "Apply without prompting for confirmation")
+	applyCmd.MarkFlagRequired("filename")
+}
+
+func printPlan(plan apply.Plan) {
+	for _, change := range plan.Changes {
+		switch change.Action {
+		case apply.ActionNoOp:
+			fmt.Printf("  %-8s %-9s %s (unchanged)\n", change.Kind, change.Action, change.Code)
+		default:
+			fmt.Printf("  %-8s %-9s %s\n", change.Kind, change.Action, change.Code)
+		}
+	}
+	if !plan.HasChanges() {
+		return
+	}
+	fmt.Println()
+}
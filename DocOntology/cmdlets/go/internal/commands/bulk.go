@@ -0,0 +1,110 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/bulk"
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// bulkCmd groups the git-friendly, dependency-ordered directory-tree
+// export/apply commands (see internal/bulk), distinct from the single-file
+// `apply`/`export`/`import` commands and the schema-only `bundle` commands.
+var bulkCmd = &cobra.Command{
+	Use:   "bulk",
+	Short: "Export and apply the whole registry as a directory tree",
+	Long:  "Round-trip doc types, countries, and schemas (with full version history) as a git-friendly tree of YAML files, applying changes in dependency order so doc types and countries are always created before the schemas that reference them.",
+}
+
+var bulkExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the whole registry to a directory tree",
+	Long:  "Write every doc type, country, and schema (with its full version history) to outDir as doc-types/<code>.yaml, countries/<code>.yaml, and schemas/<docType>/<country>/<publicId>.yaml.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		outDir, _ := cmd.Flags().GetString("out")
+
+		result, err := bulk.Export(cmd.Context(), GetClient(), outDir)
+		if err != nil {
+			return err
+		}
+
+		if output.JSONOutput {
+			return output.PrintJSON(result)
+		}
+		output.PrintSuccess(fmt.Sprintf("Exported %d doc type(s), %d countr(y/ies), %d schema(s) to %s", result.DocTypes, result.Countries, result.Schemas, outDir))
+		return nil
+	},
+}
+
+var bulkApplyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Reconcile the registry against a directory tree",
+	Long:  "Read a directory tree written by `bulk export` (or hand-authored in the same layout) and reconcile the registry to match: create what's missing, update what's drifted, and optionally prune what's unmanaged. Doc types and countries are always applied before the schemas that reference them.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, _ := cmd.Flags().GetString("filename")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		prune, _ := cmd.Flags().GetBool("prune")
+
+		tree, err := bulk.LoadTree(dir)
+		if err != nil {
+			return err
+		}
+
+		planner := bulk.NewPlanner(GetClient())
+		plan, err := planner.Plan(cmd.Context(), tree, bulk.Options{Prune: prune})
+		if err != nil {
+			return err
+		}
+
+		if output.JSONOutput {
+			return output.PrintJSON(plan)
+		}
+
+		printBulkPlan(plan)
+
+		if dryRun {
+			return nil
+		}
+		if !plan.HasChanges() {
+			output.PrintSuccess("Nothing to apply")
+			return nil
+		}
+
+		executor := bulk.NewExecutor(GetClient())
+		if _, err := executor.Execute(cmd.Context(), plan); err != nil {
+			return err
+		}
+		output.PrintSuccess("Apply complete")
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(bulkCmd)
+	bulkCmd.AddCommand(bulkExportCmd, bulkApplyCmd)
+
+	// No "-o" shorthand: it now belongs to rootCmd's persistent --output flag.
+	bulkExportCmd.Flags().String("out", "", "Output directory for the tree (required)")
+	bulkExportCmd.MarkFlagRequired("out")
+
+	bulkApplyCmd.Flags().StringP("filename", "f", "", "Directory tree to apply (required)")
+	bulkApplyCmd.Flags().Bool("dry-run", false, "Print the reconciliation plan without applying it")
+	bulkApplyCmd.Flags().Bool("prune", false, "Delete managed doc types, countries, and schemas absent from the tree")
+	bulkApplyCmd.MarkFlagRequired("filename")
+}
+
+func printBulkPlan(plan bulk.Plan) {
+	for _, change := range plan.Changes {
+		switch change.Action {
+		case bulk.ActionNoOp:
+			fmt.Printf("  %-8s %-9s %s (unchanged)\n", change.Kind, change.Action, change.Code)
+		default:
+			fmt.Printf("  %-8s %-9s %s\n", change.Kind, change.Action, change.Code)
+		}
+	}
+	if !plan.HasChanges() {
+		return
+	}
+	fmt.Println()
+}
@@ -0,0 +1,108 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/bundle"
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// bundleCmd is schema-only (no doc types/countries, no dependency
+// ordering), scoped to promoting a subset of schemas between environments —
+// see internal/bundle's doc comment for how it differs from `bulk` and
+// `export`/`import`. `schemas export`/`schemas import` used to be a second,
+// identical CLI surface over this same package; they've been folded into
+// this one command so there's a single way to do this.
+var bundleCmd = &cobra.Command{
+	Use:   "bundle",
+	Short: "Export and import versioned schema bundles",
+	Long:  "Export schemas to a portable directory bundle, or reconcile one against a registry, so schemas can be promoted between environments reproducibly.",
+}
+
+func init() {
+	rootCmd.AddCommand(bundleCmd)
+	bundleCmd.AddCommand(bundleExportCmd, bundleImportCmd)
+
+	bundleExportCmd.Flags().String("out", "", "Output directory for the bundle (required)")
+	bundleExportCmd.Flags().String("filter", "", "Limit the bundle to docType=X,country=Y")
+	bundleExportCmd.MarkFlagRequired("out")
+
+	bundleImportCmd.Flags().String("filter", "", "Limit the import to docType=X,country=Y")
+	bundleImportCmd.Flags().Bool("create-missing", false, "Create referenced doc types/countries that don't exist on the target")
+	bundleImportCmd.Flags().Bool("dry-run", false, "Print the reconciliation plan without applying it")
+	bundleImportCmd.Flags().Bool("continue-on-error", false, "Keep importing remaining schemas after one fails")
+}
+
+var bundleExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export all visible schemas to a directory bundle",
+	Long:  "Walk all schemas the caller can see and write a directory tree docType/country/name@vN.json plus a manifest.json describing the bundle.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		outDir, _ := cmd.Flags().GetString("out")
+		filterStr, _ := cmd.Flags().GetString("filter")
+
+		filter, err := bundle.ParseFilter(filterStr)
+		if err != nil {
+			return err
+		}
+
+		manifest, err := bundle.Export(cmd.Context(), GetClient(), outDir, bundle.ExportOptions{Filter: filter})
+		if err != nil {
+			return err
+		}
+
+		if output.JSONOutput {
+			return output.PrintJSON(manifest)
+		}
+		output.PrintSuccess(fmt.Sprintf("Exported %d schema(s) to %s", len(manifest.Files), outDir))
+		return nil
+	},
+}
+
+var bundleImportCmd = &cobra.Command{
+	Use:   "import <dir>",
+	Short: "Reconcile a directory bundle against the registry",
+	Long:  "Read a bundle's manifest and reconcile each schema against the target server: create if absent, no-op if unchanged, or create a new version otherwise.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir := args[0]
+		filterStr, _ := cmd.Flags().GetString("filter")
+		createMissing, _ := cmd.Flags().GetBool("create-missing")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		continueOnError, _ := cmd.Flags().GetBool("continue-on-error")
+
+		filter, err := bundle.ParseFilter(filterStr)
+		if err != nil {
+			return err
+		}
+
+		result, err := bundle.Import(cmd.Context(), GetClient(), dir, bundle.ImportOptions{
+			Filter:          filter,
+			CreateMissing:   createMissing,
+			DryRun:          dryRun,
+			ContinueOnError: continueOnError,
+		})
+		if err != nil {
+			return err
+		}
+
+		if output.JSONOutput {
+			return output.PrintJSON(result)
+		}
+
+		if dryRun {
+			fmt.Println("Dry run — no changes applied")
+		}
+		fmt.Printf("Created:   %d\n", len(result.Created))
+		fmt.Printf("Updated:   %d\n", len(result.Updated))
+		fmt.Printf("Unchanged: %d\n", len(result.Unchanged))
+		if len(result.Failed) > 0 {
+			fmt.Printf("Failed:    %d\n", len(result.Failed))
+			for _, f := range result.Failed {
+				fmt.Printf("  FAILED %s: %s\n", f.Name, f.Error)
+			}
+		}
+		return nil
+	},
+}
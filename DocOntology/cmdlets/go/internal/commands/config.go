@@ -0,0 +1,153 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/config"
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage named contexts (endpoint + API key pairs)",
+	Long: `Manage named contexts, kubeconfig-style, so you can switch between
+environments (e.g. dev, staging, prod) without retyping --api-url/--api-key.
+
+Contexts are stored in ~/.schemactl/config.yaml (override with
+$SCHEMACTL_CONFIG). The active context is chosen, in order, by --context,
+$SCHEMACTL_CONTEXT, and the file's current context.`,
+}
+
+var configSetContextCmd = &cobra.Command{
+	Use:   "set-context <name>",
+	Short: "Create or update a named context",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		url, _ := cmd.Flags().GetString("api-url")
+		key, _ := cmd.Flags().GetString("api-key")
+
+		file, err := config.LoadContextFile()
+		if err != nil {
+			return err
+		}
+
+		ctx, existed := file.Find(args[0])
+		ctx.Name = args[0]
+		if url != "" {
+			ctx.APIURL = url
+		}
+		if key != "" {
+			ctx.APIKey = key
+		}
+		if !existed && ctx.APIURL == "" {
+			return fmt.Errorf("--api-url is required when creating a new context")
+		}
+
+		file.SetContext(ctx)
+		if err := config.SaveContextFile(file); err != nil {
+			return err
+		}
+
+		output.PrintSuccess(fmt.Sprintf("Context %q set", ctx.Name))
+		return nil
+	},
+}
+
+var configUseContextCmd = &cobra.Command{
+	Use:   "use-context <name>",
+	Short: "Set the active context",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := config.LoadContextFile()
+		if err != nil {
+			return err
+		}
+		if _, ok := file.Find(args[0]); !ok {
+			return fmt.Errorf("context %q not found; run `schemactl config get-contexts` to list available contexts", args[0])
+		}
+		file.CurrentContext = args[0]
+		if err := config.SaveContextFile(file); err != nil {
+			return err
+		}
+		output.PrintSuccess(fmt.Sprintf("Switched to context %q", args[0]))
+		return nil
+	},
+}
+
+var configDeleteContextCmd = &cobra.Command{
+	Use:   "delete-context <name>",
+	Short: "Delete a named context",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := config.LoadContextFile()
+		if err != nil {
+			return err
+		}
+		if !file.RemoveContext(args[0]) {
+			return fmt.Errorf("context %q not found", args[0])
+		}
+		if err := config.SaveContextFile(file); err != nil {
+			return err
+		}
+		output.PrintSuccess(fmt.Sprintf("Context %q deleted", args[0]))
+		return nil
+	},
+}
+
+var configGetContextsCmd = &cobra.Command{
+	Use:   "get-contexts",
+	Short: "List known contexts",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := config.LoadContextFile()
+		if err != nil {
+			return err
+		}
+		if output.JSONOutput {
+			return output.PrintJSON(file)
+		}
+
+		headers := []string{"CURRENT", "NAME", "API URL"}
+		rows := make([][]string, len(file.Contexts))
+		for i, c := range file.Contexts {
+			current := ""
+			if c.Name == file.CurrentContext {
+				current = "*"
+			}
+			rows[i] = []string{current, c.Name, c.APIURL}
+		}
+		output.PrintTable(headers, rows)
+		return nil
+	},
+}
+
+var configCurrentContextCmd = &cobra.Command{
+	Use:   "current-context",
+	Short: "Print the active context's name",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := contextName
+		if name == "" {
+			cfg, err := config.Load("")
+			if err != nil {
+				return err
+			}
+			name = cfg.ActiveContext
+		}
+		if name == "" {
+			return fmt.Errorf("no context is currently active")
+		}
+		fmt.Println(name)
+		return nil
+	},
+}
+
+func init() {
+	configSetContextCmd.Flags().String("api-url", "", "API base URL for this context")
+	configSetContextCmd.Flags().String("api-key", "", "API key for this context: a literal value, @/path/to/file, or keyring:<account>")
+
+	configCmd.AddCommand(configSetContextCmd)
+	configCmd.AddCommand(configUseContextCmd)
+	configCmd.AddCommand(configDeleteContextCmd)
+	configCmd.AddCommand(configGetContextsCmd)
+	configCmd.AddCommand(configCurrentContextCmd)
+}
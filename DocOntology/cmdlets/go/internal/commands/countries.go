@@ -2,9 +2,11 @@ package commands
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/miguel-bandeira-infosistema/schemactl/internal/client"
 	"github.com/miguel-bandeira-infosistema/schemactl/internal/output"
+	"github.com/miguel-bandeira-infosistema/schemactl/pkg/geo"
 	"github.com/spf13/cobra"
 )
 
@@ -20,13 +22,23 @@ func init() {
 	countriesCmd.AddCommand(countriesCreateCmd)
 	countriesCmd.AddCommand(countriesUpdateCmd)
 	countriesCmd.AddCommand(countriesDeleteCmd)
+	countriesCmd.AddCommand(countriesSuggestCmd)
 
 	// List flags
 	countriesListCmd.Flags().Bool("all", false, "Include inactive countries")
+	countriesListCmd.Flags().String("region", "", "Filter by ISO region (e.g. Europe), from the embedded geo dataset")
+	countriesListCmd.Flags().String("subregion", "", "Filter by ISO subregion (e.g. Southern Europe), from the embedded geo dataset")
+	countriesListCmd.Flags().String("currency", "", "Filter by ISO 4217 currency code (e.g. EUR), from the embedded geo dataset")
+
+	// Create flags
+	countriesCreateCmd.Flags().Bool("force", false, "Allow creating a country whose code isn't in the embedded ISO 3166-1 dataset")
 
 	// Update flags
 	countriesUpdateCmd.Flags().StringP("name", "n", "", "New name")
 	countriesUpdateCmd.Flags().Bool("active", true, "Set active status")
+
+	// Suggest flags
+	countriesSuggestCmd.Flags().Int("limit", 5, "Maximum number of suggestions to show")
 }
 
 var countriesListCmd = &cobra.Command{
@@ -35,12 +47,29 @@ var countriesListCmd = &cobra.Command{
 	Long:  "List all countries. Use --all to include inactive ones.",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		includeAll, _ := cmd.Flags().GetBool("all")
+		region, _ := cmd.Flags().GetString("region")
+		subregion, _ := cmd.Flags().GetString("subregion")
+		currency, _ := cmd.Flags().GetString("currency")
 
-		countries, err := GetClient().ListCountries(includeAll)
+		countries, err := GetClient().ListCountries(cmd.Context(), includeAll)
 		if err != nil {
 			return err
 		}
 
+		if region != "" || subregion != "" || currency != "" {
+			allowed := map[string]bool{}
+			for _, c := range geo.Filtered(geo.Filter{Region: region, Subregion: subregion, Currency: currency}) {
+				allowed[c.Alpha2] = true
+			}
+			filtered := countries[:0]
+			for _, c := range countries {
+				if allowed[c.Code] {
+					filtered = append(filtered, c)
+				}
+			}
+			countries = filtered
+		}
+
 		if output.JSONOutput {
 			return output.PrintJSON(countries)
 		}
@@ -54,9 +83,7 @@ var countriesListCmd = &cobra.Command{
 				output.BoolString(c.IsActive),
 			}
 		}
-		output.PrintTable(headers, rows)
-
-		return nil
+		return output.PrintRows(headers, rows)
 	},
 }
 
@@ -68,7 +95,7 @@ var countriesGetCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		code := args[0]
 
-		country, err := GetClient().GetCountry(code)
+		country, err := GetClient().GetCountry(cmd.Context(), code)
 		if err != nil {
 			return err
 		}
@@ -96,12 +123,17 @@ var countriesCreateCmd = &cobra.Command{
 		code := args[0]
 		name := args[1]
 
+		force, _ := cmd.Flags().GetBool("force")
+		if !force && !geo.Known(code) {
+			return fmt.Errorf("%q is not a known ISO 3166-1 alpha-2 code; pass --force to create it anyway", code)
+		}
+
 		req := &client.CreateCountryRequest{
 			Code: code,
 			Name: name,
 		}
 
-		country, err := GetClient().CreateCountry(req)
+		country, err := GetClient().CreateCountry(cmd.Context(), req)
 		if err != nil {
 			return err
 		}
@@ -140,7 +172,7 @@ var countriesUpdateCmd = &cobra.Command{
 			return fmt.Errorf("no update fields provided")
 		}
 
-		country, err := GetClient().UpdateCountry(code, req)
+		country, err := GetClient().UpdateCountry(cmd.Context(), code, req)
 		if err != nil {
 			return err
 		}
@@ -162,7 +194,7 @@ var countriesDeleteCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		code := args[0]
 
-		if err := GetClient().DeleteCountry(code); err != nil {
+		if err := GetClient().DeleteCountry(cmd.Context(), code); err != nil {
 			return err
 		}
 
@@ -170,3 +202,26 @@ var countriesDeleteCmd = &cobra.Command{
 		return nil
 	},
 }
+
+var countriesSuggestCmd = &cobra.Command{
+	Use:   "suggest <query>",
+	Short: "Fuzzy-match a country name to its ISO code",
+	Long:  "Look up the closest-matching country names (by edit distance) in the embedded ISO 3166-1 dataset, for name-to-code lookup and shell tab-completion.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		limit, _ := cmd.Flags().GetInt("limit")
+
+		suggestions := geo.Suggest(strings.Join(args, " "), limit)
+		if output.JSONOutput {
+			return output.PrintJSON(suggestions)
+		}
+
+		headers := []string{"CODE", "NAME", "DISTANCE"}
+		rows := make([][]string, len(suggestions))
+		for i, s := range suggestions {
+			rows[i] = []string{s.Country.Alpha2, s.Country.Name, fmt.Sprintf("%d", s.Distance)}
+		}
+		output.PrintTable(headers, rows)
+		return nil
+	},
+}
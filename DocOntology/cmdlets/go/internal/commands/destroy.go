@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/apply"
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/manifest"
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// destroyCmd is apply's inverse: it removes every resource a manifest
+// describes from the registry, rather than reconciling the registry to
+// match it. Doc types and countries are (soft-)deleted, matching apply
+// --prune; schemas are deprecated rather than deleted, since a schema may
+// already have data classified against it.
+var destroyCmd = &cobra.Command{
+	Use:   "destroy",
+	Short: "Remove every resource described by a manifest file",
+	Long: `Read one or more YAML/JSON manifest documents (kind: Schema|DocType|Country)
+and remove each one from the registry: doc types and countries are
+(soft-)deleted, schemas are deprecated rather than deleted (a schema may
+already have data classified against it). A document whose resource
+doesn't exist plans a no-op, since destroying something already gone is
+the desired end state either way.
+
+--dry-run prints the plan without calling the API. Otherwise destroy
+prompts for confirmation before proceeding unless --yes is passed.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, _ := cmd.Flags().GetString("filename")
+		dryRun, _ := cmd.Flags().GetString("dry-run")
+		yes, _ := cmd.Flags().GetBool("yes")
+
+		docs, err := manifest.ParsePath(file)
+		if err != nil {
+			return err
+		}
+
+		plan, err := apply.BuildDestroy(cmd.Context(), GetClient(), docs)
+		if err != nil {
+			return err
+		}
+
+		if output.JSONOutput {
+			return output.PrintJSON(plan)
+		}
+
+		printPlan(plan)
+
+		if dryRun != "" {
+			return nil
+		}
+		if !plan.HasChanges() {
+			output.PrintSuccess("Nothing to destroy")
+			return nil
+		}
+		if !yes && !output.Confirm("Destroy the resources above?") {
+			return fmt.Errorf("destroy cancelled")
+		}
+		if err := plan.Execute(cmd.Context(), GetClient()); err != nil {
+			return err
+		}
+		output.PrintSuccess("Destroy complete")
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(destroyCmd)
+
+	destroyCmd.Flags().StringP("filename", "f", "", "Manifest file or directory to destroy (required)")
+	destroyCmd.Flags().String("dry-run", "", "If set to \"client\", print the plan without contacting the server to apply it")
+	destroyCmd.Flags().Bool("yes", false, "Destroy without prompting for confirmation")
+	destroyCmd.MarkFlagRequired("filename")
+}
@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/apply"
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/manifest"
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/output"
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/schemadiff"
+	"github.com/spf13/cobra"
+)
+
+// diffCmd prints the plan apply would execute — creates, field-level diffs
+// for updates, prunes, and no-ops — without ever calling a mutating API
+// method. It's the read-only half of apply split into its own verb, for
+// review workflows (CI comments, pre-merge checks) that want a diff without
+// needing apply's --dry-run flag.
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Show what apply would change, without applying it",
+	Long: `Read one or more YAML/JSON manifest documents (kind: Schema|DocType|Country)
+and print the plan "apply" would execute: creates, field-level diffs for
+updates, prunes (with --prune), and no-ops. Never calls a mutating API
+method — equivalent to "apply --dry-run=client" but its own top-level verb.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, _ := cmd.Flags().GetString("filename")
+		prune, _ := cmd.Flags().GetBool("prune")
+
+		docs, err := manifest.ParsePath(file)
+		if err != nil {
+			return err
+		}
+
+		plan, err := apply.Build(cmd.Context(), GetClient(), docs, apply.Options{Prune: prune})
+		if err != nil {
+			return err
+		}
+
+		if output.JSONOutput {
+			return output.PrintJSON(plan)
+		}
+
+		printPlan(plan)
+		printApplyDiffs(plan)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+
+	diffCmd.Flags().StringP("filename", "f", "", "Manifest file or directory to diff (required)")
+	diffCmd.Flags().Bool("prune", false, "Also show doc types/countries that --prune would delete")
+	diffCmd.MarkFlagRequired("filename")
+}
+
+// printApplyDiffs prints a field-level diff (via the same structural diff
+// engine as "schemas diff") for every planned update.
+func printApplyDiffs(plan apply.Plan) {
+	for _, change := range plan.Changes {
+		if change.Action != apply.ActionUpdate {
+			continue
+		}
+		changes := schemadiff.StructuralDiff(change.Before, change.After, schemadiff.StructuralOptions{})
+		if len(changes) == 0 {
+			continue
+		}
+		fmt.Printf("--- %s %s ---\n", change.Kind, change.Code)
+		printStructuralDiff(changes, nil)
+		fmt.Println()
+	}
+}
@@ -1,10 +1,16 @@
 package commands
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/miguel-bandeira-infosistema/schemactl/internal/client"
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/flags"
 	"github.com/miguel-bandeira-infosistema/schemactl/internal/output"
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/prompt"
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/query"
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/schemadiff"
 	"github.com/spf13/cobra"
 )
 
@@ -24,27 +30,76 @@ func init() {
 
 	// List flags
 	docTypesListCmd.Flags().Bool("all", false, "Include inactive doc types")
+	docTypesListCmd.Flags().String("filter", "", `Filter predicates, e.g. 'name eq "Invoice"' or 'code sw "INV" and isActive eq true'`)
+	docTypesListCmd.Flags().String("sort", "", "Sort by field[:asc|desc], e.g. name:desc")
+	docTypesListCmd.Flags().Bool("client-filter", false, "Apply --filter/--sort locally instead of as server query parameters")
 
 	// Create flags
 	docTypesCreateCmd.Flags().StringP("description", "d", "", "Doc type description")
+	docTypesCreateCmd.Flags().String("json", "", `Full CreateDocTypeRequest payload: inline JSON, @file.json, or "-" for stdin`)
 
 	// Update flags
 	docTypesUpdateCmd.Flags().StringP("name", "n", "", "New name")
 	docTypesUpdateCmd.Flags().StringP("description", "d", "", "New description")
 	docTypesUpdateCmd.Flags().Bool("active", true, "Set active status")
+	docTypesUpdateCmd.Flags().String("json", "", `Full UpdateDocTypeRequest payload: inline JSON, @file.json, or "-" for stdin`)
+	docTypesUpdateCmd.Flags().String("patch", "", `RFC 6902 JSON Patch document: inline JSON, @file.json, or "-" for stdin`)
+
+	// Delete flags
+	docTypesDeleteCmd.Flags().Bool("hard", false, "Permanently delete instead of soft-deleting (refuses if non-deprecated schemas reference the doc type, unless --cascade)")
+	docTypesDeleteCmd.Flags().Bool("cascade", false, "Also deprecate schemas referencing the doc type before --hard deleting it")
+	docTypesDeleteCmd.Flags().Bool("dry-run", false, "Print the dependency graph and what would be deleted, without deleting anything")
+	docTypesDeleteCmd.Flags().Bool("yes", false, "Delete without prompting for confirmation")
 }
 
 var docTypesListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List document types",
-	Long:  "List all document types. Use --all to include inactive ones.",
+	Long: `List all document types. Use --all to include inactive ones.
+
+--filter accepts predicates combined by a single and/or, e.g.
+'name eq "Invoice"' or 'code sw "INV" and isActive eq true' (operators: eq,
+ne, sw, ew, contains, gt, lt, ge, le). --sort accepts field[:asc|desc]. Both
+are sent to the server as "filter"/"sort" query parameters by default,
+alongside the global --fields flag (sent as "fields"), which also narrows
+the table to the named columns the way it does for every other list
+command — there's no separate --fields here.
+
+--client-filter fetches the full unfiltered/unsorted list instead and
+applies --filter/--sort locally, for servers that don't understand the
+query parameters yet.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		includeAll, _ := cmd.Flags().GetBool("all")
+		filterFlag, _ := cmd.Flags().GetString("filter")
+		sortFlag, _ := cmd.Flags().GetString("sort")
+		clientFilter, _ := cmd.Flags().GetBool("client-filter")
 
-		docTypes, err := GetClient().ListDocTypes(includeAll)
+		filter, err := query.ParseFilter(filterFlag)
 		if err != nil {
 			return err
 		}
+		sortSpec, err := query.ParseSort(sortFlag)
+		if err != nil {
+			return err
+		}
+
+		var docTypes []client.DocType
+		if clientFilter {
+			docTypes, err = GetClient().ListDocTypes(cmd.Context(), includeAll)
+			if err != nil {
+				return err
+			}
+			docTypes, err = applyDocTypeFilter(docTypes, filter, sortSpec)
+			if err != nil {
+				return err
+			}
+		} else {
+			params := query.BuildParams(filter, output.Fields, sortSpec)
+			docTypes, err = GetClient().ListDocTypesFiltered(cmd.Context(), includeAll, params)
+			if err != nil {
+				return err
+			}
+		}
 
 		if output.JSONOutput {
 			return output.PrintJSON(docTypes)
@@ -60,21 +115,83 @@ var docTypesListCmd = &cobra.Command{
 				output.BoolString(dt.IsActive),
 			}
 		}
-		output.PrintTable(headers, rows)
-
-		return nil
+		return output.PrintRows(headers, rows)
 	},
 }
 
+// applyDocTypeFilter implements docTypesListCmd's --client-filter path: it
+// round-trips each DocType through JSON to match/sort it as a plain
+// map[string]interface{} (so query.Filter/query.SortSpec, which are
+// endpoint-agnostic, can operate on it), then decodes the survivors back.
+func applyDocTypeFilter(docTypes []client.DocType, filter *query.Filter, sortSpec *query.SortSpec) ([]client.DocType, error) {
+	records := make([]map[string]interface{}, 0, len(docTypes))
+	for _, dt := range docTypes {
+		raw, err := json.Marshal(dt)
+		if err != nil {
+			return nil, err
+		}
+		var record map[string]interface{}
+		if err := json.Unmarshal(raw, &record); err != nil {
+			return nil, err
+		}
+		if filter.Match(record) {
+			records = append(records, record)
+		}
+	}
+	query.ApplySort(records, sortSpec)
+
+	filtered := make([]client.DocType, len(records))
+	for i, record := range records {
+		raw, err := json.Marshal(record)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(raw, &filtered[i]); err != nil {
+			return nil, err
+		}
+	}
+	return filtered, nil
+}
+
+// resolveDocTypeCode returns args[0] if given, otherwise prompts for one
+// from the list of active doc types (when prompting is available) so
+// get/update/delete don't hang waiting on a missing argument from a script.
+func resolveDocTypeCode(cmd *cobra.Command, args []string) (string, error) {
+	if len(args) > 0 {
+		return args[0], nil
+	}
+	if !prompt.Enabled(output.JSONOutput) {
+		return "", fmt.Errorf("accepts 1 arg(s), received 0")
+	}
+
+	docTypes, err := GetClient().ListDocTypes(cmd.Context(), false)
+	if err != nil {
+		return "", err
+	}
+	items := make([]prompt.Item, len(docTypes))
+	for i, dt := range docTypes {
+		items[i] = prompt.Item{Code: dt.Code, Label: fmt.Sprintf("%s — %s", dt.Code, dt.Name)}
+	}
+	return prompt.Select("Doc type", items)
+}
+
 var docTypesGetCmd = &cobra.Command{
-	Use:   "get <code>",
+	Use:   "get [code]",
 	Short: "Get a document type",
-	Long:  "Get a document type by its code",
-	Args:  cobra.ExactArgs(1),
+	Long: `Get a document type by its code.
+
+If code is omitted and prompting is available (an interactive terminal,
+table/non-JSON output, and --no-prompt not set), a searchable list of
+active doc types is shown to pick from instead of failing on the missing
+argument.`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		code := args[0]
+		code, err := resolveDocTypeCode(cmd, args)
+		if err != nil {
+			return err
+		}
 
-		docType, err := GetClient().GetDocType(code)
+		docType, err := GetClient().GetDocType(cmd.Context(), code)
 		if err != nil {
 			return err
 		}
@@ -95,24 +212,68 @@ var docTypesGetCmd = &cobra.Command{
 }
 
 var docTypesCreateCmd = &cobra.Command{
-	Use:   "create <code> <name>",
+	Use:   "create [code] [name]",
 	Short: "Create a document type",
-	Long:  "Create a new document type with the given code and name",
-	Args:  cobra.ExactArgs(2),
+	Long: `Create a new document type with the given code and name.
+
+--json accepts a full CreateDocTypeRequest payload — inline JSON, @file.json,
+or "-" for stdin — so scripts can pipe API-shaped payloads without composing
+flags. The code/name positional args and --description are merged on top of
+--json's fields when given, and rejected if they'd conflict with a value
+--json already set.
+
+If code/name are both omitted and --json isn't used, and prompting is
+available (an interactive terminal, table/non-JSON output, and --no-prompt
+not set), code/name/description are instead gathered by a sequence of text
+prompts.`,
+	Args: cobra.MaximumNArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		code := args[0]
-		name := args[1]
-		description, _ := cmd.Flags().GetString("description")
+		req := &client.CreateDocTypeRequest{}
+
+		jsonFlag, _ := cmd.Flags().GetString("json")
+		if jsonFlag != "" {
+			if err := flags.UnmarshalJSONFlag(jsonFlag, req); err != nil {
+				return err
+			}
+		}
 
-		req := &client.CreateDocTypeRequest{
-			Code: code,
-			Name: name,
+		if len(args) >= 1 {
+			if err := flags.MergeString(&req.Code, args[0], "code"); err != nil {
+				return err
+			}
+		}
+		if len(args) >= 2 {
+			if err := flags.MergeString(&req.Name, args[1], "name"); err != nil {
+				return err
+			}
 		}
-		if description != "" {
-			req.Description = &description
+		if description, _ := cmd.Flags().GetString("description"); description != "" {
+			if err := flags.MergeStringPtr(&req.Description, description, "description"); err != nil {
+				return err
+			}
 		}
 
-		docType, err := GetClient().CreateDocType(req)
+		if jsonFlag == "" && req.Code == "" && req.Name == "" && prompt.Enabled(output.JSONOutput) {
+			values, err := prompt.Sequence([]prompt.Field{
+				{Name: "code", Required: true},
+				{Name: "name", Required: true},
+				{Name: "description"},
+			})
+			if err != nil {
+				return err
+			}
+			req.Code = values["code"]
+			req.Name = values["name"]
+			if description := values["description"]; description != "" {
+				req.Description = &description
+			}
+		}
+
+		if req.Code == "" || req.Name == "" {
+			return fmt.Errorf("code and name are required, via positional args, --json, or the interactive prompt")
+		}
+
+		docType, err := GetClient().CreateDocType(cmd.Context(), req)
 		if err != nil {
 			return err
 		}
@@ -127,28 +288,70 @@ var docTypesCreateCmd = &cobra.Command{
 }
 
 var docTypesUpdateCmd = &cobra.Command{
-	Use:   "update <code>",
+	Use:   "update [code]",
 	Short: "Update a document type",
-	Long:  "Update a document type's name, description, or active status",
-	Args:  cobra.ExactArgs(1),
+	Long: `Update a document type's name, description, or active status.
+
+If code is omitted and prompting is available (an interactive terminal,
+table/non-JSON output, and --no-prompt not set), a searchable list of
+active doc types is shown to pick from instead of failing on the missing
+argument.
+
+--json accepts a full UpdateDocTypeRequest payload — inline JSON, @file.json,
+or "-" for stdin — so scripts can pipe API-shaped payloads without composing
+flags. --name/--description/--active are merged on top of --json's fields
+when given, and rejected if they'd conflict with a value --json already
+set.
+
+--patch instead accepts an RFC 6902 JSON Patch document (inline JSON,
+@file.json, or "-" for stdin) — an array of {op, path, value} operations
+over the doc type's own JSON representation ({code, name, description,
+isActive}). The patch is applied locally against the current doc type
+fetched via GetDocType, the result is diffed against the original to derive
+the minimal UpdateDocTypeRequest, and that's what's submitted — so, for
+example, {"op":"remove","path":"/description"} clears the description,
+something --description can't express (it can't distinguish "not set" from
+"set to empty"). --patch can't be combined with --json/--name/--description/
+--active; it derives the entire update itself.`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		code := args[0]
+		code, err := resolveDocTypeCode(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		if patchFlag, _ := cmd.Flags().GetString("patch"); patchFlag != "" {
+			return runDocTypePatch(cmd, code, patchFlag)
+		}
 
 		req := &client.UpdateDocTypeRequest{}
 		hasUpdate := false
 
+		if jsonFlag, _ := cmd.Flags().GetString("json"); jsonFlag != "" {
+			if err := flags.UnmarshalJSONFlag(jsonFlag, req); err != nil {
+				return err
+			}
+			hasUpdate = true
+		}
+
 		if name, _ := cmd.Flags().GetString("name"); name != "" {
-			req.Name = &name
+			if err := flags.MergeStringPtr(&req.Name, name, "name"); err != nil {
+				return err
+			}
 			hasUpdate = true
 		}
 		if cmd.Flags().Changed("description") {
 			description, _ := cmd.Flags().GetString("description")
-			req.Description = &description
+			if err := flags.MergeStringPtr(&req.Description, description, "description"); err != nil {
+				return err
+			}
 			hasUpdate = true
 		}
 		if cmd.Flags().Changed("active") {
 			active, _ := cmd.Flags().GetBool("active")
-			req.IsActive = &active
+			if err := flags.MergeBoolPtr(&req.IsActive, active, "active"); err != nil {
+				return err
+			}
 			hasUpdate = true
 		}
 
@@ -156,7 +359,7 @@ var docTypesUpdateCmd = &cobra.Command{
 			return fmt.Errorf("no update fields provided")
 		}
 
-		docType, err := GetClient().UpdateDocType(code, req)
+		docType, err := GetClient().UpdateDocType(cmd.Context(), code, req)
 		if err != nil {
 			return err
 		}
@@ -170,15 +373,205 @@ var docTypesUpdateCmd = &cobra.Command{
 	},
 }
 
+// runDocTypePatch implements docTypesUpdateCmd's --patch path: fetch the
+// current doc type, apply the patch locally, derive the minimal
+// UpdateDocTypeRequest from what changed, and submit it.
+func runDocTypePatch(cmd *cobra.Command, code, patchFlag string) error {
+	if jsonFlag, _ := cmd.Flags().GetString("json"); jsonFlag != "" {
+		return fmt.Errorf("--patch cannot be combined with --json")
+	}
+	if cmd.Flags().Changed("name") || cmd.Flags().Changed("description") || cmd.Flags().Changed("active") {
+		return fmt.Errorf("--patch cannot be combined with --name/--description/--active")
+	}
+
+	patchData, err := flags.ReadJSON(patchFlag)
+	if err != nil {
+		return err
+	}
+	var ops []schemadiff.JSONPatchOp
+	if err := json.Unmarshal(patchData, &ops); err != nil {
+		return fmt.Errorf("invalid --patch payload: %w", err)
+	}
+
+	existing, err := GetClient().GetDocType(cmd.Context(), code)
+	if err != nil {
+		return err
+	}
+
+	req, err := docTypePatchRequest(existing, ops)
+	if err != nil {
+		return err
+	}
+
+	docType, err := GetClient().UpdateDocType(cmd.Context(), code, req)
+	if err != nil {
+		return err
+	}
+
+	if output.JSONOutput {
+		return output.PrintJSON(docType)
+	}
+
+	output.PrintSuccess(fmt.Sprintf("Doc type updated: %s", docType.Code))
+	return nil
+}
+
+// docTypePatchRequest applies ops to existing's JSON representation and
+// derives the minimal UpdateDocTypeRequest needed to reach that result. The
+// net result, not the individual ops, is what's validated: a patch that
+// tries to change the immutable /code is rejected here rather than letting
+// it silently no-op.
+func docTypePatchRequest(existing *client.DocType, ops []schemadiff.JSONPatchOp) (*client.UpdateDocTypeRequest, error) {
+	raw, err := json.Marshal(existing)
+	if err != nil {
+		return nil, err
+	}
+	var original map[string]interface{}
+	if err := json.Unmarshal(raw, &original); err != nil {
+		return nil, err
+	}
+
+	patchedRaw, err := schemadiff.ApplyPatch(original, ops)
+	if err != nil {
+		return nil, err
+	}
+	patched, ok := patchedRaw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("patch result is not a JSON object")
+	}
+
+	if code, _ := patched["code"].(string); code != existing.Code {
+		return nil, fmt.Errorf("patch changes /code, which is immutable")
+	}
+
+	req := &client.UpdateDocTypeRequest{}
+	hasUpdate := false
+
+	if name, _ := patched["name"].(string); name != existing.Name {
+		req.Name = &name
+		hasUpdate = true
+	}
+
+	existingDescription := ""
+	if existing.Description != nil {
+		existingDescription = *existing.Description
+	}
+	patchedDescriptionRaw, hasDescription := patched["description"]
+	patchedDescription, _ := patchedDescriptionRaw.(string)
+	if hasDescription && !isStringValue(patchedDescriptionRaw) {
+		return nil, fmt.Errorf("/description must be a string")
+	}
+	if patchedDescription != existingDescription {
+		req.Description = &patchedDescription
+		hasUpdate = true
+	}
+
+	if active, ok := patched["isActive"].(bool); ok && active != existing.IsActive {
+		req.IsActive = &active
+		hasUpdate = true
+	}
+
+	if !hasUpdate {
+		return nil, fmt.Errorf("patch results in no changes")
+	}
+	return req, nil
+}
+
+func isStringValue(v interface{}) bool {
+	_, ok := v.(string)
+	return ok
+}
+
 var docTypesDeleteCmd = &cobra.Command{
-	Use:   "delete <code>",
+	Use:   "delete [code]",
 	Short: "Delete a document type",
-	Long:  "Soft delete a document type (sets isActive to false)",
-	Args:  cobra.ExactArgs(1),
+	Long: `Soft delete a document type by default (sets isActive to false).
+
+If code is omitted and prompting is available (an interactive terminal,
+table/non-JSON output, and --no-prompt not set), a searchable list of
+active doc types is shown to pick from instead of failing on the missing
+argument.
+
+--hard permanently deletes the doc type instead (DELETE ...?force=true) and
+refuses to proceed if any non-deprecated schema still references it,
+unless --cascade is also given — in which case every such schema is
+deprecated first (schemas have no hard delete of their own; deprecating is
+their soft-delete). --dry-run prints the dependency graph (the referencing
+schemas) and what would be deleted, without deleting anything. Without
+--yes, the dependency graph is printed and confirmation is required before
+either kind of delete proceeds.`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		code := args[0]
+		code, err := resolveDocTypeCode(cmd, args)
+		if err != nil {
+			return err
+		}
+		hard, _ := cmd.Flags().GetBool("hard")
+		cascade, _ := cmd.Flags().GetBool("cascade")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		yes, _ := cmd.Flags().GetBool("yes")
+
+		if cascade && !hard {
+			return fmt.Errorf("--cascade only applies to --hard delete")
+		}
 
-		if err := GetClient().DeleteDocType(code); err != nil {
+		dependents, err := listAllSchemasByDocType(cmd.Context(), code)
+		if err != nil {
+			return err
+		}
+
+		var active []client.SchemaWithRelations
+		for _, s := range dependents {
+			if s.Status != client.StatusDeprecated {
+				active = append(active, s)
+			}
+		}
+
+		if len(dependents) > 0 {
+			fmt.Printf("Doc type %s is referenced by %d schema(s):\n", code, len(dependents))
+			for _, s := range dependents {
+				fmt.Printf("  - %s (%s, status: %s)\n", s.PublicID, s.Name, s.Status)
+			}
+		}
+
+		if hard && len(active) > 0 && !cascade {
+			return fmt.Errorf("doc type %s has %d schema(s) that aren't deprecated; pass --cascade to deprecate them first, or delete without --hard", code, len(active))
+		}
+
+		if dryRun {
+			verb := "soft-delete"
+			if hard {
+				verb = "hard-delete"
+			}
+			msg := fmt.Sprintf("Dry run: would %s doc type %s", verb, code)
+			if hard && cascade && len(active) > 0 {
+				msg += fmt.Sprintf(" (after deprecating %d referencing schema(s))", len(active))
+			}
+			output.PrintSuccess(msg)
+			return nil
+		}
+
+		if !yes && !output.Confirm(fmt.Sprintf("Delete doc type %s?", code)) {
+			return fmt.Errorf("delete cancelled")
+		}
+
+		if hard && cascade {
+			for _, s := range active {
+				if _, err := GetClient().DeprecateSchema(cmd.Context(), s.PublicID); err != nil {
+					return fmt.Errorf("deprecating schema %s: %w", s.PublicID, err)
+				}
+			}
+		}
+
+		if hard {
+			if err := GetClient().HardDeleteDocType(cmd.Context(), code); err != nil {
+				return err
+			}
+			output.PrintSuccess(fmt.Sprintf("Doc type permanently deleted: %s", code))
+			return nil
+		}
+
+		if err := GetClient().DeleteDocType(cmd.Context(), code); err != nil {
 			return err
 		}
 
@@ -186,3 +579,26 @@ var docTypesDeleteCmd = &cobra.Command{
 		return nil
 	},
 }
+
+// listAllSchemasByDocType returns every schema referencing code, paginating
+// through ListSchemas rather than trusting the server's default page size —
+// docTypesDeleteCmd's dependency pre-flight (refuse --hard, or run --cascade)
+// would otherwise undercount on a doc type with more than one page of
+// referencing schemas.
+func listAllSchemasByDocType(ctx context.Context, code string) ([]client.SchemaWithRelations, error) {
+	const pageSize = 100
+	var dependents []client.SchemaWithRelations
+	offset := 0
+	for {
+		page, err := GetClient().ListSchemas(ctx, &client.ListSchemasOptions{DocType: &code, Limit: pageSize, Offset: offset})
+		if err != nil {
+			return nil, err
+		}
+		dependents = append(dependents, page.Data...)
+		if !page.Pagination.HasMore {
+			break
+		}
+		offset += pageSize
+	}
+	return dependents, nil
+}
@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/archive"
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/bundle"
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(exportCmd, importCmd)
+
+	exportCmd.Flags().String("out", "", "Output archive path (required)")
+	exportCmd.Flags().String("filter", "", "Limit the archive to doc-type=X,country=Y")
+	exportCmd.MarkFlagRequired("out")
+
+	importCmd.Flags().Bool("dry-run", false, "Print the reconciliation plan without applying it")
+	importCmd.Flags().String("on-conflict", "version", "How to resolve resources that already exist: skip, overwrite, or version")
+}
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the full registry to a portable archive",
+	Long:  "Serialize every doc type, country, and (filtered) schema into a single tar+gzip archive containing manifest.json, doc-types.json, countries.json, and one schemas/<publicId>.json per schema.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		outPath, _ := cmd.Flags().GetString("out")
+		filterStr, _ := cmd.Flags().GetString("filter")
+
+		filter, err := bundle.ParseFilter(filterStr)
+		if err != nil {
+			return err
+		}
+
+		f, err := os.Create(outPath)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", outPath, err)
+		}
+		defer f.Close()
+
+		manifest, err := archive.Export(cmd.Context(), GetClient(), f, archive.ExportOptions{
+			Filter:     filter,
+			FilterExpr: filterStr,
+			Progress:   func(msg string) { fmt.Fprintln(os.Stderr, msg) },
+		})
+		if err != nil {
+			return err
+		}
+
+		if output.JSONOutput {
+			return output.PrintJSON(manifest)
+		}
+		output.PrintSuccess(fmt.Sprintf("Exported %d doc type(s), %d countr(y/ies), %d schema(s) to %s", manifest.DocTypes, manifest.Countries, manifest.Schemas, outPath))
+		return nil
+	},
+}
+
+var importCmd = &cobra.Command{
+	Use:   "import <archive>",
+	Short: "Restore an archive produced by `export` into the registry",
+	Long:  "Read a tar+gzip archive and reconcile its doc types, countries, and schemas against the target server, rolling back every applied change if any resource fails partway through.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		archivePath := args[0]
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		onConflictStr, _ := cmd.Flags().GetString("on-conflict")
+
+		onConflict, err := archive.ParseOnConflict(onConflictStr)
+		if err != nil {
+			return err
+		}
+
+		f, err := os.Open(archivePath)
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %w", archivePath, err)
+		}
+		defer f.Close()
+
+		result, err := archive.Import(cmd.Context(), GetClient(), f, archive.ImportOptions{
+			DryRun:     dryRun,
+			OnConflict: onConflict,
+			Progress:   func(msg string) { fmt.Fprintln(os.Stderr, msg) },
+		})
+		if err != nil {
+			return err
+		}
+
+		if output.JSONOutput {
+			return output.PrintJSON(result)
+		}
+
+		if dryRun {
+			fmt.Println("Dry run — no changes applied")
+		}
+		fmt.Printf("Created: %d\n", result.Created)
+		fmt.Printf("Updated: %d\n", result.Updated)
+		fmt.Printf("Skipped: %d\n", result.Skipped)
+		return nil
+	},
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/miguel-bandeira-infosistema/schemactl/internal/output"
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/watch"
 	"github.com/spf13/cobra"
 )
 
@@ -12,29 +13,39 @@ var healthCmd = &cobra.Command{
 	Short: "Check API health",
 	Long:  "Check the health status of the API server and database connection",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		health, err := GetClient().Health()
-		if err != nil {
-			return err
+		fetch := func() error {
+			health, err := GetClient().Health(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			if output.JSONOutput {
+				return output.PrintJSON(health)
+			}
+
+			statusIcon := "OK"
+			if health.Status != "ok" {
+				statusIcon = "ERROR"
+			}
+
+			dbIcon := "Connected"
+			if health.Database != "connected" {
+				dbIcon = "Disconnected"
+			}
+
+			fmt.Printf("Status:    %s\n", statusIcon)
+			fmt.Printf("Database:  %s\n", dbIcon)
+			fmt.Printf("Timestamp: %s\n", health.Timestamp.Format("2006-01-02 15:04:05"))
+			return nil
 		}
 
-		if output.JSONOutput {
-			return output.PrintJSON(health)
+		if watchFlag, _ := cmd.Flags().GetBool("watch"); watchFlag {
+			return watch.Run(cmd.Context(), watchInterval(cmd), fetch)
 		}
-
-		statusIcon := "OK"
-		if health.Status != "ok" {
-			statusIcon = "ERROR"
-		}
-
-		dbIcon := "Connected"
-		if health.Database != "connected" {
-			dbIcon = "Disconnected"
-		}
-
-		fmt.Printf("Status:    %s\n", statusIcon)
-		fmt.Printf("Database:  %s\n", dbIcon)
-		fmt.Printf("Timestamp: %s\n", health.Timestamp.Format("2006-01-02 15:04:05"))
-
-		return nil
+		return fetch()
 	},
 }
+
+func init() {
+	addWatchFlags(healthCmd)
+}
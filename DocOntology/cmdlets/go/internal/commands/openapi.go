@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/config"
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/openapi"
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/output"
+	"gopkg.in/yaml.v3"
+
+	"github.com/spf13/cobra"
+)
+
+var openapiCmd = &cobra.Command{
+	Use:   "openapi",
+	Short: "Generate an OpenAPI 3.0 spec for the Schema Registry API",
+	Long:  "Generate an OpenAPI 3.0 document describing every route this CLI calls, derived from the internal/client request and response types.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		out, _ := cmd.Flags().GetString("out")
+
+		apiURL := config.DefaultAPIURL
+		if cfg != nil {
+			apiURL = cfg.APIBaseURL
+		}
+
+		doc := openapi.Build(apiURL)
+
+		var data []byte
+		var err error
+		switch format {
+		case "json":
+			data, err = json.MarshalIndent(doc, "", "  ")
+		case "yaml", "":
+			data, err = yaml.Marshal(doc)
+		default:
+			return fmt.Errorf("unsupported format %q (use json or yaml)", format)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to marshal OpenAPI document: %w", err)
+		}
+
+		if out == "" {
+			fmt.Println(string(data))
+			return nil
+		}
+		if err := os.WriteFile(out, data, 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", out, err)
+		}
+		output.PrintSuccess(fmt.Sprintf("OpenAPI spec written to %s", out))
+		return nil
+	},
+}
+
+func init() {
+	openapiCmd.Flags().String("format", "yaml", "Output format (yaml, json)")
+	openapiCmd.Flags().String("out", "", "Write to a file instead of stdout")
+	rootCmd.AddCommand(openapiCmd)
+}
@@ -2,9 +2,13 @@ package commands
 
 import (
 	"fmt"
+	"os"
 
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/client"
 	"github.com/miguel-bandeira-infosistema/schemactl/internal/output"
+	"github.com/miguel-bandeira-infosistema/schemactl/pkg/geo"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 )
 
 var referenceDataCmd = &cobra.Command{
@@ -13,15 +17,33 @@ var referenceDataCmd = &cobra.Command{
 	Short:   "Get all reference data",
 	Long:    "Get all active doc types and countries in a single request",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		data, err := GetClient().GetReferenceData()
+		enrich, _ := cmd.Flags().GetBool("enrich")
+
+		data, err := GetClient().GetReferenceData(cmd.Context())
 		if err != nil {
 			return err
 		}
 
 		if output.JSONOutput {
+			if enrich {
+				return output.PrintJSON(enrichReferenceData(data))
+			}
 			return output.PrintJSON(data)
 		}
 
+		// --output yaml has no natural two-section row-stream shape (it's
+		// one document), so it's served from the same combined struct as
+		// --output json rather than through PrintRows below.
+		if output.ActiveFormat == output.FormatYAML {
+			var v interface{} = data
+			if enrich {
+				v = enrichReferenceData(data)
+			}
+			enc := yaml.NewEncoder(os.Stdout)
+			defer enc.Close()
+			return enc.Encode(v)
+		}
+
 		fmt.Println("Document Types:")
 		fmt.Println("---------------")
 		headers := []string{"CODE", "NAME", "DESCRIPTION"}
@@ -33,21 +55,63 @@ var referenceDataCmd = &cobra.Command{
 				output.Truncate(output.PtrString(dt.Description, "-"), 40),
 			}
 		}
-		output.PrintTable(headers, rows)
+		if err := output.PrintRows(headers, rows); err != nil {
+			return err
+		}
 
 		fmt.Println()
 		fmt.Println("Countries:")
 		fmt.Println("----------")
-		headers = []string{"CODE", "NAME"}
-		rows = make([][]string, len(data.Countries))
-		for i, c := range data.Countries {
-			rows[i] = []string{
-				c.Code,
-				c.Name,
+		if enrich {
+			headers = []string{"CODE", "NAME", "REGION", "SUBREGION", "CURRENCY", "CALLING CODE"}
+			rows = make([][]string, len(data.Countries))
+			for i, c := range data.Countries {
+				iso, _ := geo.Lookup(c.Code)
+				rows[i] = []string{c.Code, c.Name, iso.Region, iso.Subregion, iso.Currency, iso.CallingCode}
+			}
+		} else {
+			headers = []string{"CODE", "NAME"}
+			rows = make([][]string, len(data.Countries))
+			for i, c := range data.Countries {
+				rows[i] = []string{
+					c.Code,
+					c.Name,
+				}
 			}
 		}
-		output.PrintTable(headers, rows)
-
-		return nil
+		return output.PrintRows(headers, rows)
 	},
 }
+
+func init() {
+	referenceDataCmd.Flags().Bool("enrich", false, "Include ISO region/subregion/currency/calling-code metadata from the embedded geo dataset")
+}
+
+// enrichedCountry pairs a registry country with its embedded ISO metadata,
+// for `reference-data --enrich --json`.
+type enrichedCountry struct {
+	Code        string `json:"code"`
+	Name        string `json:"name"`
+	Region      string `json:"region,omitempty"`
+	Subregion   string `json:"subregion,omitempty"`
+	Currency    string `json:"currency,omitempty"`
+	CallingCode string `json:"callingCode,omitempty"`
+}
+
+type enrichedReferenceData struct {
+	DocTypes  []client.DocType  `json:"docTypes"`
+	Countries []enrichedCountry `json:"countries"`
+}
+
+func enrichReferenceData(data *client.ReferenceDataResponse) enrichedReferenceData {
+	countries := make([]enrichedCountry, len(data.Countries))
+	for i, c := range data.Countries {
+		iso, _ := geo.Lookup(c.Code)
+		countries[i] = enrichedCountry{
+			Code: c.Code, Name: c.Name,
+			Region: iso.Region, Subregion: iso.Subregion,
+			Currency: iso.Currency, CallingCode: iso.CallingCode,
+		}
+	}
+	return enrichedReferenceData{DocTypes: data.DocTypes, Countries: countries}
+}
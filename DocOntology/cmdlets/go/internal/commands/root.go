@@ -1,11 +1,16 @@
 package commands
 
 import (
+	"context"
 	"os"
+	"os/signal"
+	"strings"
+	"time"
 
 	"github.com/miguel-bandeira-infosistema/schemactl/internal/client"
 	"github.com/miguel-bandeira-infosistema/schemactl/internal/config"
 	"github.com/miguel-bandeira-infosistema/schemactl/internal/output"
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/prompt"
 	"github.com/spf13/cobra"
 )
 
@@ -14,13 +19,27 @@ var (
 	Version = "dev"
 
 	// Global flags
-	apiURL  string
-	apiKey  string
-	jsonOut bool
+	apiURL       string
+	apiKey       string
+	jsonOut      bool
+	contextName  string
+	outputFormat string
+	templateText string
+	fields       string
+	timeoutFlag  time.Duration
+	noPrompt     bool
+
+	// Unix domain socket and mutual TLS overrides, see config.Config.
+	apiSocket          string
+	clientCert         string
+	clientKey          string
+	caCert             string
+	insecureSkipVerify bool
 
 	// Shared client
-	apiClient *client.Client
-	cfg       *config.Config
+	apiClient     *client.Client
+	cfg           *config.Config
+	cancelTimeout context.CancelFunc
 )
 
 var rootCmd = &cobra.Command{
@@ -37,6 +56,11 @@ Environment variables:
   SCHEMACTL_API_URL    API base URL (overrides default)
   SCHEMACTL_API_KEY    API key for authentication (optional)
   SCHEMACTL_TIMEOUT    Request timeout in seconds (default: 30)
+  SCHEMACTL_CONTEXT    Named context to use (see 'schemactl config')
+  SCHEMACTL_API_SOCKET Unix domain socket path to dial instead of TCP
+  SCHEMACTL_CLIENT_CERT Client certificate file for mutual TLS
+  SCHEMACTL_CLIENT_KEY  Client private key file for mutual TLS
+  SCHEMACTL_CA_CERT     CA certificate file to verify the server
 
 Run without arguments to enter interactive shell mode.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -44,47 +68,107 @@ Run without arguments to enter interactive shell mode.`,
 		return RunShell()
 	},
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-		// Skip initialization for help and version commands
-		if cmd.Name() == "help" || cmd.Name() == "version" {
+		// Skip initialization for help, version, and config commands, none
+		// of which need a client.
+		if cmd.Name() == "help" || cmd.Name() == "version" || cmd.Parent() == configCmd || cmd == configCmd {
 			return nil
 		}
 
 		// Load configuration
 		var err error
-		cfg, err = config.Load()
+		cfg, err = config.Load(contextName)
 		if err != nil {
 			return err
 		}
 
 		// Apply command-line overrides
-		cfg.WithAPIURL(apiURL).WithAPIKey(apiKey)
+		cfg.WithAPIURL(apiURL)
+		if err := cfg.SetAPIKey(apiKey); err != nil {
+			return err
+		}
+		cfg.WithAPISocketPath(apiSocket)
+		cfg.WithClientTLS(clientCert, clientKey, caCert)
+		if cmd.Flags().Changed("insecure-skip-verify") {
+			cfg.InsecureSkipVerify = insecureSkipVerify
+		}
 
 		// Validate configuration
 		if err := cfg.Validate(); err != nil {
 			return err
 		}
 
-		// Set JSON output mode
-		output.JSONOutput = jsonOut
+		// Resolve --output/-o/--template/--fields
+		format, err := output.ParseFormat(outputFormat)
+		if err != nil {
+			return err
+		}
+		// --json is a deprecated alias for --output json: it wins only when
+		// --output itself wasn't also given explicitly.
+		if jsonOut && !cmd.Flags().Changed("output") {
+			format = output.FormatJSON
+		}
+		output.ActiveFormat = format
+		output.TemplateText = templateText
+		output.Fields = nil
+		if fields != "" {
+			output.Fields = strings.Split(fields, ",")
+		}
+		// Set JSON output mode for commands that still branch on the plain
+		// JSONOutput bool instead of going through a RowWriter.
+		output.JSONOutput = format == output.FormatJSON || format == output.FormatJSONL
+		prompt.NoPrompt = noPrompt
 
 		// Create API client
-		apiClient = client.New(cfg)
+		apiClient, err = client.New(cfg)
+		if err != nil {
+			return err
+		}
+
+		// Apply --timeout as a deadline on the command's context, so every
+		// client call derived from cmd.Context() is bounded even though the
+		// client's own http.Client.Timeout is fixed at construction time.
+		if timeoutFlag > 0 {
+			ctx, cancel := context.WithTimeout(cmd.Context(), timeoutFlag)
+			cancelTimeout = cancel
+			cmd.SetContext(ctx)
+		}
 
 		return nil
 	},
+	PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
+		if cancelTimeout != nil {
+			cancelTimeout()
+		}
+		return nil
+	},
 	SilenceUsage:  true,
 	SilenceErrors: true,
 }
 
-// Execute runs the root command
+// Execute runs the root command. Its context is cancelled on SIGINT, so a
+// `--watch` loop can stop cleanly instead of being killed mid-render.
 func Execute() error {
-	return rootCmd.Execute()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	return rootCmd.ExecuteContext(ctx)
 }
 
 func init() {
 	rootCmd.PersistentFlags().StringVar(&apiURL, "api-url", "", "API base URL (env: SCHEMACTL_API_URL)")
-	rootCmd.PersistentFlags().StringVar(&apiKey, "api-key", "", "API key (env: SCHEMACTL_API_KEY)")
-	rootCmd.PersistentFlags().BoolVar(&jsonOut, "json", false, "Output as JSON")
+	rootCmd.PersistentFlags().StringVar(&apiKey, "api-key", "", "API key: a literal value, @/path/to/file, or keyring:<account> (env: SCHEMACTL_API_KEY)")
+	rootCmd.PersistentFlags().BoolVar(&jsonOut, "json", false, "Output as JSON (deprecated, use --output json)")
+	rootCmd.PersistentFlags().MarkDeprecated("json", "use --output json (or -o json) instead")
+	rootCmd.PersistentFlags().StringVar(&contextName, "context", "", "Named context to use (env: SCHEMACTL_CONTEXT)")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "table", "Output format: table, json, jsonl, yaml, csv, tsv, or template")
+	rootCmd.PersistentFlags().StringVar(&templateText, "template", "", "Go text/template body, used when --output=template")
+	rootCmd.PersistentFlags().StringVar(&fields, "fields", "", "Comma-separated column names to project, e.g. code,name,description")
+	rootCmd.PersistentFlags().DurationVar(&timeoutFlag, "timeout", 0, "Cancel the request if it hasn't completed after this long (0 disables)")
+	rootCmd.PersistentFlags().StringVar(&apiSocket, "api-socket", "", "Unix domain socket path to dial instead of TCP (env: SCHEMACTL_API_SOCKET)")
+	rootCmd.PersistentFlags().StringVar(&clientCert, "client-cert", "", "Client certificate file for mutual TLS (env: SCHEMACTL_CLIENT_CERT)")
+	rootCmd.PersistentFlags().StringVar(&clientKey, "client-key", "", "Client private key file for mutual TLS (env: SCHEMACTL_CLIENT_KEY)")
+	rootCmd.PersistentFlags().StringVar(&caCert, "ca-cert", "", "CA certificate file to verify the server, instead of the system roots (env: SCHEMACTL_CA_CERT)")
+	rootCmd.PersistentFlags().BoolVar(&insecureSkipVerify, "insecure-skip-verify", false, "Skip TLS certificate verification (insecure; local/dev use only)")
+	rootCmd.PersistentFlags().BoolVar(&noPrompt, "no-prompt", false, "Never prompt interactively for missing arguments; fail instead (default on for non-TTY/JSON output already)")
 
 	// Add subcommands
 	rootCmd.AddCommand(versionCmd)
@@ -93,6 +177,7 @@ func init() {
 	rootCmd.AddCommand(docTypesCmd)
 	rootCmd.AddCommand(countriesCmd)
 	rootCmd.AddCommand(referenceDataCmd)
+	rootCmd.AddCommand(configCmd)
 }
 
 var versionCmd = &cobra.Command{
@@ -0,0 +1,105 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/client"
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// schemaCmd groups version-level lifecycle and inspection commands, as
+// opposed to schemasCmd which manages schema resources.
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Schema lifecycle and inspection commands",
+	Long:  "Commands that act on a single schema version: lifecycle transitions and history.",
+}
+
+func init() {
+	rootCmd.AddCommand(schemaCmd)
+
+	schemaCmd.AddCommand(
+		lifecycleCmd(client.ActionSubmit, "submit <publicVersionId>", "Submit a draft schema for review"),
+		lifecycleCmd(client.ActionRelease, "release <publicVersionId>", "Release a draft schema to active status"),
+		lifecycleCmd(client.ActionDeprecate, "deprecate <publicVersionId>", "Deprecate an active schema"),
+		lifecycleCmd(client.ActionRecover, "recover <publicVersionId>", "Recover a deprecated schema back to active"),
+		lifecycleCmd(client.ActionReject, "reject <publicVersionId>", "Reject a draft schema"),
+		schemaHistoryCmd,
+		schemaDiffCmd,
+	)
+}
+
+// lifecycleCmd builds one of the typed lifecycle-transition subcommands.
+// Each validates the transition client-side against the schema's current
+// status before calling Client.PerformAction.
+func lifecycleCmd(action client.Action, use, short string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: short,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			publicVersionID := args[0]
+			message, _ := cmd.Flags().GetString("message")
+
+			current, err := GetClient().GetSchemaVersion(cmd.Context(), publicVersionID)
+			if err != nil {
+				return err
+			}
+
+			if _, err := client.ValidateTransition(current.Status, action); err != nil {
+				return err
+			}
+
+			updated, err := GetClient().PerformAction(cmd.Context(), publicVersionID, client.ActionRequest{
+				Action:  action,
+				Message: message,
+			})
+			if err != nil {
+				return err
+			}
+
+			if output.JSONOutput {
+				return output.PrintJSON(updated)
+			}
+
+			output.PrintSuccess(fmt.Sprintf("Schema %s transitioned %s -> %s", updated.PublicVersionID, current.Status, updated.Status))
+			return nil
+		},
+	}
+	cmd.Flags().StringP("message", "m", "", "Audit message recorded with this transition")
+	return cmd
+}
+
+var schemaHistoryCmd = &cobra.Command{
+	Use:   "history <publicId>",
+	Short: "List a schema's lifecycle transitions",
+	Long:  "List the recorded lifecycle transitions for a schema, with timestamps and audit messages.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		publicID := args[0]
+
+		events, err := GetClient().GetSchemaHistory(cmd.Context(), publicID)
+		if err != nil {
+			return err
+		}
+
+		if output.JSONOutput {
+			return output.PrintJSON(events)
+		}
+
+		headers := []string{"TIME", "ACTION", "FROM", "TO", "MESSAGE"}
+		rows := make([][]string, len(events))
+		for i, e := range events {
+			rows[i] = []string{
+				e.CreatedAt.Format("2006-01-02 15:04:05"),
+				string(e.Action),
+				string(e.FromState),
+				string(e.ToState),
+				output.Truncate(e.Message, 40),
+			}
+		}
+		output.PrintTable(headers, rows)
+		return nil
+	},
+}
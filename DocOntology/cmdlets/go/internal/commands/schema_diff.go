@@ -0,0 +1,204 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/output"
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/schemadiff"
+	"github.com/spf13/cobra"
+)
+
+var schemaDiffCmd = &cobra.Command{
+	Use:   "diff <idA> <idB>",
+	Short: "Field-level diff between two schemas or versions",
+	Long: `Fetch two schemas — each identified by a publicId (sch_xxx) or a
+publicVersionId (schv_xxx) — and print a structural diff of their Content:
+"+ path: value" for additions, "- path: value" for removals, and
+"~ path: before -> after" for changes, colored when stdout is a terminal
+(disabled under --json or when NO_COLOR is set).
+
+With --file @path.json, idB is omitted and the second side is read from the
+file instead, so a local draft can be compared against a registered version.
+
+Arrays are matched by index unless --array-key "path[field]" names a nested
+field to match elements by instead, e.g. "properties[name]" pairs elements of
+the array at path "properties" by their "name" field rather than position.
+
+Under --json, the diff is emitted as an RFC 6902 JSON Patch array instead of
+the text report. --exit-code fails the command if idA and idB differ, so CI
+can gate on schema drift from an approved baseline.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fileFlag, _ := cmd.Flags().GetString("file")
+		arrayKeyFlag, _ := cmd.Flags().GetString("array-key")
+		exitCode, _ := cmd.Flags().GetBool("exit-code")
+		corpusFile, _ := cmd.Flags().GetString("corpus")
+
+		if fileFlag == "" && len(args) != 2 {
+			return fmt.Errorf("diff requires two identifiers, or one identifier plus --file")
+		}
+		if fileFlag != "" && len(args) != 1 {
+			return fmt.Errorf("diff takes exactly one identifier when --file is set")
+		}
+
+		before, beforePattern, err := resolveDiffSide(cmd, args[0])
+		if err != nil {
+			return err
+		}
+
+		var after map[string]interface{}
+		var afterPattern string
+		if fileFlag != "" {
+			after, err = parseContent(fileFlag)
+			if err != nil {
+				return err
+			}
+			afterPattern, _ = after["pattern"].(string)
+		} else {
+			after, afterPattern, err = resolveDiffSide(cmd, args[1])
+			if err != nil {
+				return err
+			}
+		}
+
+		diffOpts, err := parseArrayKeyFlag(arrayKeyFlag)
+		if err != nil {
+			return err
+		}
+
+		changes := schemadiff.StructuralDiff(before, after, diffOpts)
+
+		var regressions []schemadiff.RegexRegression
+		if corpusFile != "" && beforePattern != "" && afterPattern != "" {
+			regressions, err = diffCorpusFile(beforePattern, afterPattern, corpusFile)
+			if err != nil {
+				return err
+			}
+		}
+
+		if output.JSONOutput {
+			if err := output.PrintJSON(map[string]interface{}{
+				"patch":            schemadiff.ToJSONPatch(changes),
+				"regexRegressions": regressions,
+			}); err != nil {
+				return err
+			}
+			if exitCode && len(changes) > 0 {
+				os.Exit(1)
+			}
+			return nil
+		}
+
+		printStructuralDiff(changes, regressions)
+
+		if exitCode && len(changes) > 0 {
+			os.Exit(1)
+		}
+		return nil
+	},
+}
+
+func init() {
+	schemaDiffCmd.Flags().String("file", "", "Compare idA against a local draft instead of a second identifier, e.g. --file @draft.json")
+	schemaDiffCmd.Flags().String("array-key", "", `Match array elements by a nested field instead of by index, e.g. "properties[name]"`)
+	schemaDiffCmd.Flags().Bool("exit-code", false, "Exit non-zero when the two sides differ")
+	schemaDiffCmd.Flags().String("corpus", "", "Newline-separated sample strings to test regex-typed schemas against")
+}
+
+// resolveDiffSide fetches the schema identified by id — GetSchema accepts
+// both a publicId and a publicVersionId — and returns its content along
+// with its pattern (if any), for an optional regex corpus comparison.
+func resolveDiffSide(cmd *cobra.Command, id string) (map[string]interface{}, string, error) {
+	s, err := GetClient().GetSchema(cmd.Context(), id)
+	if err != nil {
+		return nil, "", err
+	}
+	pattern, _ := s.Content["pattern"].(string)
+	return s.Content, pattern, nil
+}
+
+func diffCorpusFile(beforePattern, afterPattern, corpusFile string) ([]schemadiff.RegexRegression, error) {
+	data, err := os.ReadFile(corpusFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read corpus file: %w", err)
+	}
+	corpus := strings.Split(strings.TrimSpace(string(data)), "\n")
+	return schemadiff.CompareCorpus(beforePattern, afterPattern, corpus)
+}
+
+var arrayKeyPattern = regexp.MustCompile(`^(.+)\[(.+)\]$`)
+
+// parseArrayKeyFlag parses a "path[field]" flag value into a
+// schemadiff.StructuralOptions, e.g. "properties[name]" matches elements of
+// the array at path "properties" by their "name" field instead of by index.
+func parseArrayKeyFlag(s string) (schemadiff.StructuralOptions, error) {
+	if s == "" {
+		return schemadiff.StructuralOptions{}, nil
+	}
+	m := arrayKeyPattern.FindStringSubmatch(s)
+	if m == nil {
+		return schemadiff.StructuralOptions{}, fmt.Errorf(`invalid --array-key %q, expected "path[field]"`, s)
+	}
+	return schemadiff.StructuralOptions{ArrayPath: m[1], ArrayKeyField: m[2]}, nil
+}
+
+const (
+	diffAddColor    = "\033[32m"
+	diffRemoveColor = "\033[31m"
+	diffChangeColor = "\033[33m"
+	diffColorReset  = "\033[0m"
+)
+
+// printStructuralDiff prints one line per change in "+"/"-"/"~" diff style,
+// colored when stdout is a terminal and NO_COLOR isn't set, followed by a
+// summary of added/removed/modified counts.
+func printStructuralDiff(changes []schemadiff.PathChange, regressions []schemadiff.RegexRegression) {
+	if len(changes) == 0 {
+		output.PrintSuccess("No differences")
+		return
+	}
+
+	color := isTerminal(os.Stdout) && os.Getenv("NO_COLOR") == ""
+
+	var added, removed, modified int
+	for _, c := range changes {
+		switch c.Op {
+		case schemadiff.OpAdd:
+			added++
+			fmt.Println(colorize(diffAddColor, fmt.Sprintf("+ %s: %v", c.Path, c.After), color))
+		case schemadiff.OpRemove:
+			removed++
+			fmt.Println(colorize(diffRemoveColor, fmt.Sprintf("- %s: %v", c.Path, c.Before), color))
+		case schemadiff.OpChange:
+			modified++
+			fmt.Println(colorize(diffChangeColor, fmt.Sprintf("~ %s: %v -> %v", c.Path, c.Before, c.After), color))
+		}
+	}
+
+	if len(regressions) > 0 {
+		fmt.Println("\nRegex corpus regressions:")
+		for _, r := range regressions {
+			fmt.Printf("  %q: matched before=%v after=%v\n", r.Sample, r.MatchedBefore, r.MatchedAfter)
+		}
+	}
+
+	fmt.Printf("\n%d added, %d removed, %d modified\n", added, removed, modified)
+}
+
+func colorize(code, s string, enabled bool) string {
+	if !enabled {
+		return s
+	}
+	return code + s + diffColorReset
+}
+
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -4,11 +4,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 
 	"github.com/miguel-bandeira-infosistema/schemactl/internal/client"
 	"github.com/miguel-bandeira-infosistema/schemactl/internal/output"
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/watch"
 	"github.com/spf13/cobra"
 )
 
@@ -26,11 +28,15 @@ func init() {
 	schemasCmd.AddCommand(schemasActivateCmd)
 	schemasCmd.AddCommand(schemasDeprecateCmd)
 	schemasCmd.AddCommand(schemasDeleteCmd)
+	schemasCmd.AddCommand(schemasSummaryCmd)
+	schemasCmd.AddCommand(schemasVerifyCmd)
 	schemasCmd.AddCommand(schemasFindBestCmd)
 	schemasCmd.AddCommand(schemasVersionsCmd)
-	schemasCmd.AddCommand(schemasMatchCmd)
 	schemasCmd.AddCommand(schemasGenerateCmd)
 
+	addWatchFlags(schemasGetCmd)
+	addWatchFlags(schemasVersionsCmd)
+
 	// List flags
 	schemasListCmd.Flags().String("status", "", "Filter by status (draft, active, deprecated)")
 	schemasListCmd.Flags().StringP("doc-type", "t", "", "Filter by doc type code")
@@ -49,6 +55,7 @@ func init() {
 	schemasCreateCmd.Flags().StringP("visibility", "v", "private", "Visibility (public, community, private)")
 	schemasCreateCmd.Flags().String("schema-type", "standard", "Schema type (standard, regex)")
 	schemasCreateCmd.Flags().String("customer-id", "", "Customer ID")
+	schemasCreateCmd.Flags().Bool("validate", true, "Validate and lint content locally before submitting")
 	schemasCreateCmd.MarkFlagRequired("name")
 	schemasCreateCmd.MarkFlagRequired("doc-type")
 	schemasCreateCmd.MarkFlagRequired("content")
@@ -61,6 +68,7 @@ func init() {
 	schemasUpdateCmd.Flags().StringP("country", "c", "", "Country code")
 	schemasUpdateCmd.Flags().StringP("visibility", "v", "", "Visibility (public, community, private)")
 	schemasUpdateCmd.Flags().String("schema-type", "", "Schema type (standard, regex)")
+	schemasUpdateCmd.Flags().Bool("validate", true, "Validate and lint content locally before submitting")
 
 	// Find-best flags
 	schemasFindBestCmd.Flags().StringP("doc-type", "t", "", "Doc type code (required)")
@@ -68,9 +76,6 @@ func init() {
 	schemasFindBestCmd.Flags().String("customer-id", "", "Customer ID")
 	schemasFindBestCmd.MarkFlagRequired("doc-type")
 
-	// Match flags
-	schemasMatchCmd.Flags().String("customer-id", "", "Customer ID for private schema matching")
-
 	// Generate flags
 	schemasGenerateCmd.Flags().StringP("file", "f", "", "Path to PDF or JPEG file")
 	schemasGenerateCmd.Flags().String("text", "", "Raw text content (alternative to file)")
@@ -108,7 +113,7 @@ var schemasListCmd = &cobra.Command{
 		opts.Limit, _ = cmd.Flags().GetInt("limit")
 		opts.Offset, _ = cmd.Flags().GetInt("offset")
 
-		result, err := GetClient().ListSchemas(opts)
+		result, err := GetClient().ListSchemas(cmd.Context(), opts)
 		if err != nil {
 			return err
 		}
@@ -131,7 +136,9 @@ var schemasListCmd = &cobra.Command{
 				string(s.Visibility),
 			}
 		}
-		output.PrintTable(headers, rows)
+		if err := output.PrintRows(headers, rows); err != nil {
+			return err
+		}
 
 		if result.Pagination.HasMore {
 			fmt.Printf("\nShowing %d of %d schemas (use --offset to see more)\n",
@@ -150,17 +157,22 @@ var schemasGetCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		id := args[0]
 
-		schema, err := GetClient().GetSchema(id)
-		if err != nil {
-			return err
+		fetch := func() error {
+			schema, err := GetClient().GetSchema(cmd.Context(), id)
+			if err != nil {
+				return err
+			}
+			if output.JSONOutput {
+				return output.PrintJSON(schema)
+			}
+			printSchemaDetails(schema)
+			return nil
 		}
 
-		if output.JSONOutput {
-			return output.PrintJSON(schema)
+		if watchFlag, _ := cmd.Flags().GetBool("watch"); watchFlag {
+			return watch.Run(cmd.Context(), watchInterval(cmd), fetch)
 		}
-
-		printSchemaDetails(schema)
-		return nil
+		return fetch()
 	},
 }
 
@@ -183,6 +195,12 @@ var schemasCreateCmd = &cobra.Command{
 			return fmt.Errorf("invalid content: %w", err)
 		}
 
+		if doValidate, _ := cmd.Flags().GetBool("validate"); doValidate {
+			if err := validateContentLocally(content, schemaType); err != nil {
+				return err
+			}
+		}
+
 		req := &client.CreateSchemaRequest{
 			Name:        name,
 			DocTypeCode: docType,
@@ -207,7 +225,7 @@ var schemasCreateCmd = &cobra.Command{
 			req.CustomerID = &customerID
 		}
 
-		schema, err := GetClient().CreateSchema(req)
+		schema, err := GetClient().CreateSchema(cmd.Context(), req)
 		if err != nil {
 			return err
 		}
@@ -245,6 +263,12 @@ var schemasUpdateCmd = &cobra.Command{
 			if err != nil {
 				return fmt.Errorf("invalid content: %w", err)
 			}
+			if doValidate, _ := cmd.Flags().GetBool("validate"); doValidate {
+				schemaType, _ := cmd.Flags().GetString("schema-type")
+				if err := validateContentLocally(content, schemaType); err != nil {
+					return err
+				}
+			}
 			req.Content = content
 			hasUpdate = true
 		}
@@ -271,7 +295,7 @@ var schemasUpdateCmd = &cobra.Command{
 			return fmt.Errorf("no update fields provided")
 		}
 
-		schema, err := GetClient().UpdateSchema(id, req)
+		schema, err := GetClient().UpdateSchema(cmd.Context(), id, req)
 		if err != nil {
 			return err
 		}
@@ -293,7 +317,7 @@ var schemasActivateCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		id := args[0]
 
-		schema, err := GetClient().ActivateSchema(id)
+		schema, err := GetClient().ActivateSchema(cmd.Context(), id)
 		if err != nil {
 			return err
 		}
@@ -315,7 +339,7 @@ var schemasDeprecateCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		id := args[0]
 
-		schema, err := GetClient().DeprecateSchema(id)
+		schema, err := GetClient().DeprecateSchema(cmd.Context(), id)
 		if err != nil {
 			return err
 		}
@@ -337,7 +361,7 @@ var schemasDeleteCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		id := args[0]
 
-		if err := GetClient().DeleteSchema(id); err != nil {
+		if err := GetClient().DeleteSchema(cmd.Context(), id); err != nil {
 			return err
 		}
 
@@ -346,6 +370,39 @@ var schemasDeleteCmd = &cobra.Command{
 	},
 }
 
+var schemasSummaryCmd = &cobra.Command{
+	Use:   "summary <id>",
+	Short: "Print a schema's content-addressed SHA-256 summary",
+	Long:  "Compute the SHA-256 summary of a schema's content (publicId or publicVersionId), the same digest sent as X-Schema-Summary on create/update.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		summary, err := GetClient().GetSchemaSummary(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+
+		if output.JSONOutput {
+			return output.PrintJSON(map[string]string{"publicId": args[0], "summary": summary})
+		}
+		fmt.Println(summary)
+		return nil
+	},
+}
+
+var schemasVerifyCmd = &cobra.Command{
+	Use:   "verify <id>",
+	Short: "Verify a schema's stored summary matches its content",
+	Long:  "Fetch a schema (publicId or publicVersionId), recompute its content summary, and compare it against the X-Schema-Summary header the server returns alongside it, reporting any mismatch.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := GetClient().VerifySchema(cmd.Context(), args[0]); err != nil {
+			return err
+		}
+		output.PrintSuccess(fmt.Sprintf("Schema summary verified: %s", args[0]))
+		return nil
+	},
+}
+
 var schemasFindBestCmd = &cobra.Command{
 	Use:   "find-best",
 	Short: "Find best matching schema",
@@ -365,7 +422,7 @@ var schemasFindBestCmd = &cobra.Command{
 			req.CustomerID = &customerID
 		}
 
-		result, err := GetClient().FindBestSchema(req)
+		result, err := GetClient().FindBestSchema(cmd.Context(), req)
 		if err != nil {
 			return err
 		}
@@ -393,74 +450,38 @@ var schemasVersionsCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		id := args[0]
 
-		versions, err := GetClient().GetSchemaVersions(id)
-		if err != nil {
-			return err
-		}
-
-		if output.JSONOutput {
-			return output.PrintJSON(versions)
-		}
-
-		headers := []string{"VERSION ID", "VERSION", "STATUS", "CREATED AT"}
-		rows := make([][]string, len(versions))
-		for i, v := range versions {
-			rows[i] = []string{
-				v.PublicVersionID,
-				strconv.Itoa(v.Version),
-				string(v.Status),
-				v.CreatedAt.Format("2006-01-02 15:04:05"),
+		fetch := func() error {
+			versions, err := GetClient().GetSchemaVersions(cmd.Context(), id)
+			if err != nil {
+				return err
 			}
-		}
-		output.PrintTable(headers, rows)
-
-		return nil
-	},
-}
-
-var schemasMatchCmd = &cobra.Command{
-	Use:   "match <file>",
-	Short: "Match schema to file",
-	Long:  "Upload a PDF or JPEG file to classify and find matching schema",
-	Args:  cobra.ExactArgs(1),
-	RunE: func(cmd *cobra.Command, args []string) error {
-		filePath := args[0]
-		customerID, _ := cmd.Flags().GetString("customer-id")
 
-		var custIDPtr *string
-		if customerID != "" {
-			custIDPtr = &customerID
-		}
-
-		result, err := GetClient().MatchSchema(filePath, custIDPtr)
-		if err != nil {
-			return err
-		}
+			if output.JSONOutput {
+				return output.PrintJSON(versions)
+			}
 
-		if output.JSONOutput {
-			return output.PrintJSON(result)
+			headers := []string{"VERSION ID", "VERSION", "STATUS", "CREATED AT"}
+			rows := make([][]string, len(versions))
+			for i, v := range versions {
+				rows[i] = []string{
+					v.PublicVersionID,
+					strconv.Itoa(v.Version),
+					string(v.Status),
+					v.CreatedAt.Format("2006-01-02 15:04:05"),
+				}
+			}
+			output.PrintTable(headers, rows)
+			return nil
 		}
 
-		fmt.Println("Classification:")
-		fmt.Printf("  Doc Type: %s\n", result.Classification.DocType)
-		fmt.Printf("  Country:  %s\n", result.Classification.Country)
-		fmt.Printf("  Pages:    %v\n", result.Classification.Pages)
-		fmt.Println()
-
-		if result.Schema != nil {
-			fmt.Println("Matched Schema:")
-			fmt.Printf("  ID:         %s\n", result.Schema.PublicID)
-			fmt.Printf("  Version ID: %s\n", result.Schema.PublicVersionID)
-			fmt.Printf("  Name:       %s\n", result.Schema.Name)
-			fmt.Printf("  Type:       %s\n", result.Schema.SchemaType)
-		} else {
-			fmt.Println("No matching schema found")
+		if watchFlag, _ := cmd.Flags().GetBool("watch"); watchFlag {
+			return watch.Run(cmd.Context(), watchInterval(cmd), fetch)
 		}
-
-		return nil
+		return fetch()
 	},
 }
 
+
 var schemasGenerateCmd = &cobra.Command{
 	Use:   "generate",
 	Short: "Generate schema from document",
@@ -500,7 +521,11 @@ Examples:
 			UseOCR:      useOCR,
 		}
 
-		result, err := GetClient().GenerateSchema(req)
+		var progress func(bytesSent, totalBytes int64)
+		if filePath != "" {
+			progress = output.NewProgressFunc(filepath.Base(filePath))
+		}
+		result, err := GetClient().GenerateSchema(cmd.Context(), req, progress)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,142 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/apply"
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/manifest"
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/output"
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/schemadiff"
+	"github.com/spf13/cobra"
+)
+
+// schemasApplyCmd is a schema-only, git-friendly declarative apply, distinct
+// from the top-level, multi-kind `apply` command: it matches existing
+// schemas by customerId in addition to name+docType+country (or an explicit
+// metadata.code publicId override), drives spec.targetStatus through the
+// lifecycle, and records a manifest content hash so later applies can
+// detect out-of-band drift instead of clobbering it.
+var schemasApplyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Reconcile schemas against a manifest file (declarative, git-friendly)",
+	Long: `Read one or many Schema-kind manifest documents (YAML or JSON,
+multi-document YAML streams supported) and reconcile the registry's schemas
+to match: create what's missing, update what's drifted, and optionally
+prune what's unmanaged.
+
+Each manifest is matched against an existing schema by metadata.code (an
+explicit publicId override) if set, otherwise by
+metadata.name+docType+country+customerId. spec.targetStatus (draft, active,
+or deprecated) drives the matched schema through the lifecycle via
+ActivateSchema/DeprecateSchema after its content is reconciled; "draft" is
+only reachable for a schema that hasn't left draft yet.
+
+The manifest's content hash is recorded in the schema's description, so a
+later apply can tell a schema that's drifted out-of-band (edited directly
+against the API since the last apply) from one it still fully owns, and
+refuses to overwrite it silently.
+
+--dry-run=client prints the plan, including a field-level diff of each
+update via the same engine as "schemas diff", without applying it.
+--dry-run=server additionally validates the plan against the server without
+committing it (currently identical to --dry-run=client: the API has no
+dedicated dry-run endpoint yet).
+
+--prune deprecates (not deletes — a schema may already have data classified
+against it) managed schemas absent from the manifest set, scoped by
+--selector "customer-id=X" so a partial manifest can't reach outside its
+intended blast radius.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, _ := cmd.Flags().GetString("filename")
+		dryRun, _ := cmd.Flags().GetString("dry-run")
+		prune, _ := cmd.Flags().GetBool("prune")
+		selector, _ := cmd.Flags().GetString("selector")
+
+		if dryRun != "" && dryRun != "client" && dryRun != "server" {
+			return fmt.Errorf(`--dry-run must be "client" or "server", got %q`, dryRun)
+		}
+
+		docs, err := manifest.ParsePath(file)
+		if err != nil {
+			return err
+		}
+
+		plan, err := apply.BuildSchemaApply(cmd.Context(), GetClient(), docs, apply.SchemaOptions{Prune: prune, Selector: selector})
+		if err != nil {
+			return err
+		}
+
+		if output.JSONOutput {
+			return output.PrintJSON(plan)
+		}
+
+		printPlan(plan)
+		if dryRun == "client" || dryRun == "server" {
+			printSchemaApplyDiffs(plan)
+		}
+		printSchemaApplySummary(plan)
+
+		if dryRun != "" {
+			return nil
+		}
+		if !plan.HasChanges() {
+			output.PrintSuccess("Nothing to apply")
+			return nil
+		}
+		if err := plan.Execute(cmd.Context(), GetClient()); err != nil {
+			return err
+		}
+		output.PrintSuccess("Apply complete")
+		return nil
+	},
+}
+
+func init() {
+	schemasCmd.AddCommand(schemasApplyCmd)
+
+	schemasApplyCmd.Flags().StringP("filename", "f", "", "Schema manifest file or directory to apply (required)")
+	schemasApplyCmd.Flags().String("dry-run", "", `If set to "client" or "server", print the plan without applying it`)
+	schemasApplyCmd.Flags().Bool("prune", false, "Deprecate managed schemas absent from the manifest set (requires --selector)")
+	schemasApplyCmd.Flags().String("selector", "", `Scope matching and --prune to "customer-id=<value>"`)
+	schemasApplyCmd.MarkFlagRequired("filename")
+}
+
+// printSchemaApplyDiffs prints a field-level diff (via the same structural
+// diff engine as "schemas diff") for every planned content update.
+func printSchemaApplyDiffs(plan apply.Plan) {
+	for _, change := range plan.Changes {
+		if change.Action != apply.ActionUpdate {
+			continue
+		}
+		before, _ := change.Before["content"]
+		after, _ := change.After["content"]
+		changes := schemadiff.StructuralDiff(before, after, schemadiff.StructuralOptions{})
+		if len(changes) == 0 {
+			continue
+		}
+		fmt.Printf("--- %s ---\n", change.Code)
+		printStructuralDiff(changes, nil)
+		fmt.Println()
+	}
+}
+
+// printSchemaApplySummary prints the final created/updated/transitioned/
+// unchanged/pruned counts.
+func printSchemaApplySummary(plan apply.Plan) {
+	var created, updated, transitioned, unchanged, pruned int
+	for _, change := range plan.Changes {
+		switch change.Action {
+		case apply.ActionCreate:
+			created++
+		case apply.ActionUpdate:
+			updated++
+		case apply.ActionTransition:
+			transitioned++
+		case apply.ActionNoOp:
+			unchanged++
+		case apply.ActionPrune:
+			pruned++
+		}
+	}
+	fmt.Printf("%d created, %d updated, %d transitioned, %d unchanged, %d pruned\n", created, updated, transitioned, unchanged, pruned)
+}
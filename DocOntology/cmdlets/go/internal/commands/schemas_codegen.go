@@ -0,0 +1,145 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/client"
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/codegen"
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/output"
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/watch"
+	"github.com/spf13/cobra"
+)
+
+// schemasCodegenCmd generates strongly-typed source bindings from a
+// registered schema's JSON Schema content, via internal/codegen.
+var schemasCodegenCmd = &cobra.Command{
+	Use:   "codegen [id]",
+	Short: "Generate strongly-typed source bindings from a schema's content",
+	Long: `Fetch one schema (by publicId or publicVersionId) or, with --all,
+every active schema matching --doc-type/--country/--customer-id, and
+generate strongly-typed source bindings from its JSON Schema content:
+
+  --lang go      one struct per object, a typed string constant block per
+                 enum, and an interface-plus-variants-plus-UnmarshalJSON box
+                 per oneOf/anyOf union
+  --lang ts      one "export interface" per object, a string-literal union
+                 per enum, and a plain union type per oneOf/anyOf
+  --lang python  one pydantic BaseModel per object, a str Enum per enum, and
+                 typing.Union per oneOf/anyOf
+
+$ref is resolved against the schema's own definitions/$defs, emitting one
+named type per definition rather than inlining it.
+
+Generated files are written under --out, one per schema, named from its
+publicId. --watch re-fetches on every interval (ListSchemas with --all,
+GetSchema otherwise) and regenerates a schema's file whenever its version
+changes, so downstream services stay in sync with the registry without a
+manual step.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		langFlag, _ := cmd.Flags().GetString("lang")
+		pkg, _ := cmd.Flags().GetString("package")
+		outDir, _ := cmd.Flags().GetString("out")
+		all, _ := cmd.Flags().GetBool("all")
+		docType, _ := cmd.Flags().GetString("doc-type")
+		country, _ := cmd.Flags().GetString("country")
+		customerID, _ := cmd.Flags().GetString("customer-id")
+
+		if all && len(args) != 0 {
+			return fmt.Errorf("codegen takes no positional id when --all is set")
+		}
+		if !all && len(args) != 1 {
+			return fmt.Errorf("codegen requires a schema id, or --all with a filter")
+		}
+
+		gen, err := codegen.ForLanguage(codegen.Language(langFlag))
+		if err != nil {
+			return err
+		}
+
+		generated := map[string]int{}
+		generate := func() error {
+			schemas, err := fetchCodegenSchemas(cmd, all, args, docType, country, customerID)
+			if err != nil {
+				return err
+			}
+			if err := os.MkdirAll(outDir, 0o755); err != nil {
+				return fmt.Errorf("failed to create %s: %w", outDir, err)
+			}
+			for _, s := range schemas {
+				if v, ok := generated[s.PublicID]; ok && v == s.Version {
+					continue
+				}
+				src, err := gen.Generate(codegen.Schema{PublicID: s.PublicID, Name: s.Name, Content: s.Content}, s.Name, pkg)
+				if err != nil {
+					return err
+				}
+				path := filepath.Join(outDir, s.PublicID+"."+gen.FileExtension())
+				if err := os.WriteFile(path, src, 0o644); err != nil {
+					return fmt.Errorf("failed to write %s: %w", path, err)
+				}
+				output.PrintSuccess(fmt.Sprintf("Generated %s", path))
+				generated[s.PublicID] = s.Version
+			}
+			return nil
+		}
+
+		if watchFlag, _ := cmd.Flags().GetBool("watch"); watchFlag {
+			return watch.Run(cmd.Context(), watchInterval(cmd), generate)
+		}
+		return generate()
+	},
+}
+
+func init() {
+	schemasCmd.AddCommand(schemasCodegenCmd)
+	addWatchFlags(schemasCodegenCmd)
+
+	schemasCodegenCmd.Flags().String("lang", "go", "Target language: go, ts, or python")
+	schemasCodegenCmd.Flags().String("package", "models", "Package name for generated Go source (ignored by ts/python)")
+	schemasCodegenCmd.Flags().String("out", "", "Output directory for generated files (required)")
+	schemasCodegenCmd.Flags().Bool("all", false, "Generate every active schema matching --doc-type/--country/--customer-id instead of a single id")
+	schemasCodegenCmd.Flags().String("doc-type", "", "Filter for --all")
+	schemasCodegenCmd.Flags().String("country", "", "Filter for --all")
+	schemasCodegenCmd.Flags().String("customer-id", "", "Filter for --all")
+	schemasCodegenCmd.MarkFlagRequired("out")
+}
+
+// fetchCodegenSchemas resolves the schema(s) codegen should run over: a
+// single id, or every active schema matching the --all filters.
+func fetchCodegenSchemas(cmd *cobra.Command, all bool, args []string, docType, country, customerID string) ([]client.SchemaWithRelations, error) {
+	if !all {
+		s, err := GetClient().GetSchema(cmd.Context(), args[0])
+		if err != nil {
+			return nil, err
+		}
+		return []client.SchemaWithRelations{*s}, nil
+	}
+
+	opts := &client.ListSchemasOptions{Limit: 100}
+	status := client.StatusActive
+	opts.Status = &status
+	if docType != "" {
+		opts.DocType = &docType
+	}
+	if country != "" {
+		opts.Country = &country
+	}
+	if customerID != "" {
+		opts.CustomerID = &customerID
+	}
+	var schemas []client.SchemaWithRelations
+	for {
+		page, err := GetClient().ListSchemas(cmd.Context(), opts)
+		if err != nil {
+			return nil, err
+		}
+		schemas = append(schemas, page.Data...)
+		if !page.Pagination.HasMore {
+			return schemas, nil
+		}
+		opts.Offset += opts.Limit
+	}
+}
@@ -0,0 +1,73 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/client"
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/watch"
+	"github.com/spf13/cobra"
+)
+
+// schemasEventsCmd streams schema activation/version changes. The API has
+// no SSE/WebSocket endpoint to subscribe to, so it's emulated by polling
+// `schemas list` and diffing each schema's (status, version) pair against
+// the previous poll.
+var schemasEventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "Stream schema status/version changes",
+	Long:  "Poll the schema list and print an event each time a schema's status or version changes, until interrupted.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		docType, _ := cmd.Flags().GetString("doc-type")
+		country, _ := cmd.Flags().GetString("country")
+		interval, _ := cmd.Flags().GetDuration("interval")
+
+		opts := &client.ListSchemasOptions{Limit: 200}
+		if docType != "" {
+			opts.DocType = &docType
+		}
+		if country != "" {
+			opts.Country = &country
+		}
+
+		type observed struct {
+			status  client.Status
+			version int
+		}
+		seen := map[string]observed{}
+		first := true
+
+		fetch := func() error {
+			page, err := GetClient().ListSchemas(cmd.Context(), opts)
+			if err != nil {
+				return err
+			}
+
+			for _, s := range page.Data {
+				o := observed{status: s.Status, version: s.Version}
+				prev, ok := seen[s.PublicID]
+				seen[s.PublicID] = o
+
+				switch {
+				case !ok:
+					if !first {
+						fmt.Printf("[%s] created   %s v%d (%s)\n", time.Now().Format("15:04:05"), s.PublicID, s.Version, s.Status)
+					}
+				case prev != o:
+					fmt.Printf("[%s] changed   %s v%d->%d %s->%s\n", time.Now().Format("15:04:05"), s.PublicID, prev.version, s.Version, prev.status, s.Status)
+				}
+			}
+			first = false
+			return nil
+		}
+
+		return watch.Run(cmd.Context(), interval, fetch)
+	},
+}
+
+func init() {
+	schemasCmd.AddCommand(schemasEventsCmd)
+	schemasEventsCmd.Flags().StringP("doc-type", "t", "", "Filter by doc type code")
+	schemasEventsCmd.Flags().StringP("country", "c", "", "Filter by country code")
+	schemasEventsCmd.Flags().Duration("interval", 3*time.Second, "Polling interval")
+}
@@ -0,0 +1,380 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/client"
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/output"
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/validate"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// lintFinding is one meta-validation error or style issue found in a file.
+// Line is best-effort: the line of the finding's JSON-pointer leaf key in
+// the source file, or the nearest ancestor's line if the leaf can't be
+// located exactly.
+type lintFinding struct {
+	Level   string `json:"level"` // "error" or "warning"
+	Pointer string `json:"pointer"`
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+// lintFileResult is one file's lint findings. Pass is false if any Level
+// "error" finding was recorded — Level "warning" findings alone don't fail
+// the file.
+type lintFileResult struct {
+	Path     string        `json:"path"`
+	Pass     bool          `json:"pass"`
+	Findings []lintFinding `json:"findings,omitempty"`
+}
+
+var schemasLintCmd = &cobra.Command{
+	Use:   "lint [path]",
+	Short: "Validate local schema files without contacting the registry",
+	Long: `Walk path (a file, or a directory of .json/.yaml/.yml files) and, for each
+file, meta-validate its content against JSON Schema and this registry's
+style conventions (missing $id/title/description, unbounded
+additionalProperties, a regex pattern that fails to compile), the same
+passes schemas create/update apply before a request ever reaches the API.
+Prints a per-file pass/fail summary with line numbers for each finding and
+exits non-zero if any file has errors, so it can gate a pre-commit hook or
+CI pipeline.
+
+With --strict, also enforce conventions specific to this registry: a
+non-empty description, and --schema-type naming a valid client.SchemaType.
+
+With --doc-type, lint is skipped and the command instead reproduces
+find-best's ranking locally against a directory of candidate schemas laid
+out docType/country/name.ext (as written by bulk export/bundle export),
+so users can check what the server would match without round-tripping
+through the API.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := "."
+		if len(args) == 1 {
+			path = args[0]
+		}
+		schemaType, _ := cmd.Flags().GetString("schema-type")
+		strict, _ := cmd.Flags().GetBool("strict")
+		docType, _ := cmd.Flags().GetString("doc-type")
+		country, _ := cmd.Flags().GetString("country")
+		customerID, _ := cmd.Flags().GetString("customer-id")
+
+		if docType != "" {
+			return runLocalFindBest(path, docType, country, customerID)
+		}
+
+		files, err := walkSchemaFiles(path)
+		if err != nil {
+			return err
+		}
+
+		var results []lintFileResult
+		failed := 0
+		for _, file := range files {
+			result, err := lintFile(file, schemaType, strict)
+			if err != nil {
+				return err
+			}
+			if !result.Pass {
+				failed++
+			}
+			results = append(results, result)
+		}
+
+		if output.JSONOutput {
+			if err := output.PrintJSON(results); err != nil {
+				return err
+			}
+		} else {
+			printLintResults(results)
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d of %d file(s) failed", failed, len(results))
+		}
+		return nil
+	},
+}
+
+func init() {
+	schemasCmd.AddCommand(schemasLintCmd)
+	schemasLintCmd.Flags().String("schema-type", "standard", "Schema type (standard, regex)")
+	schemasLintCmd.Flags().Bool("strict", false, "Also enforce registry-specific conventions (non-empty description, valid --schema-type)")
+	schemasLintCmd.Flags().StringP("doc-type", "t", "", "Skip linting; instead reproduce find-best's ranking locally against path")
+	schemasLintCmd.Flags().StringP("country", "c", "", "Country code to match, used with --doc-type")
+	schemasLintCmd.Flags().String("customer-id", "", "Customer ID, used with --doc-type (accepted for parity with find-best; it has no effect on local ranking)")
+}
+
+// validateContentLocally runs the meta-validation and style-lint passes
+// `schemas create`/`schemas update` apply before a request ever reaches the
+// API, printing findings and returning an error if any were fatal.
+func validateContentLocally(content map[string]interface{}, schemaType string) error {
+	errs, err := validate.Content(content, validate.Options{})
+	if err != nil {
+		return err
+	}
+	for _, e := range errs {
+		fmt.Println("error: " + e.String())
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%d validation error(s); rerun with --validate=false to skip", len(errs))
+	}
+
+	for _, issue := range validate.Lint(content, schemaType) {
+		fmt.Println("warning: " + issue.String())
+	}
+	return nil
+}
+
+// walkSchemaFiles returns path itself if it's a file, or every
+// .json/.yaml/.yml file under it (sorted, for stable output) if it's a
+// directory.
+func walkSchemaFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	var files []string
+	err = filepath.Walk(path, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if fi.IsDir() {
+			return nil
+		}
+		switch strings.ToLower(filepath.Ext(p)) {
+		case ".json", ".yaml", ".yml":
+			files = append(files, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk %s: %w", path, err)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// lintFile meta-validates and style-lints a single schema file. A parse
+// failure is reported as a failing file rather than a command error, so one
+// malformed file in a directory doesn't abort the whole run.
+func lintFile(path, schemaType string, strict bool) (lintFileResult, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return lintFileResult{}, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var content map[string]interface{}
+	if err := yaml.Unmarshal(data, &content); err != nil {
+		return lintFileResult{
+			Path: path,
+			Findings: []lintFinding{
+				{Level: "error", Line: 1, Message: fmt.Sprintf("failed to parse: %s", err)},
+			},
+		}, nil
+	}
+
+	result := lintFileResult{Path: path, Pass: true}
+
+	errs, err := validate.Content(content, validate.Options{Strict: strict})
+	if err != nil {
+		return lintFileResult{}, fmt.Errorf("%s: %w", path, err)
+	}
+	for _, e := range errs {
+		result.Pass = false
+		result.Findings = append(result.Findings, lintFinding{
+			Level: "error", Pointer: e.Pointer, Line: lineForPointer(data, e.Pointer), Message: e.Description,
+		})
+	}
+
+	for _, issue := range validate.Lint(content, schemaType) {
+		result.Findings = append(result.Findings, lintFinding{
+			Level: "warning", Pointer: issue.Pointer, Line: lineForPointer(data, issue.Pointer), Message: issue.Message,
+		})
+	}
+
+	if strict {
+		for _, f := range strictFindings(content, schemaType) {
+			f.Line = lineForPointer(data, f.Pointer)
+			result.Pass = false
+			result.Findings = append(result.Findings, f)
+		}
+	}
+
+	return result, nil
+}
+
+// strictFindings enforces conventions specific to this registry, beyond
+// generic JSON Schema meta-validation: a non-empty description, and a
+// --schema-type naming one of the registry's client.SchemaType values.
+func strictFindings(content map[string]interface{}, schemaType string) []lintFinding {
+	var findings []lintFinding
+
+	if desc, ok := content["description"].(string); !ok || strings.TrimSpace(desc) == "" {
+		findings = append(findings, lintFinding{Level: "error", Pointer: "/description", Message: "strict mode requires a non-empty description"})
+	}
+
+	switch client.SchemaType(schemaType) {
+	case client.SchemaTypeStandard, client.SchemaTypeRegex:
+	default:
+		findings = append(findings, lintFinding{
+			Level: "error", Pointer: "/",
+			Message: fmt.Sprintf("strict mode requires --schema-type to be %q or %q, got %q", client.SchemaTypeStandard, client.SchemaTypeRegex, schemaType),
+		})
+	}
+
+	return findings
+}
+
+// lineForPointer locates pointer (a JSON Pointer, or the dot-separated
+// "(root).foo.bar" form validate.Content's errors use) within data's YAML
+// parse tree and returns the 1-based line of its leaf key. It falls back to
+// the nearest ancestor's line if the leaf segment can't be found, and to
+// line 1 if data doesn't parse as YAML at all (which JSON always does).
+func lineForPointer(data []byte, pointer string) int {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil || len(root.Content) == 0 {
+		return 1
+	}
+
+	node := root.Content[0]
+	line := node.Line
+	normalized := strings.NewReplacer(".", "/", "(root)", "").Replace(pointer)
+	for _, seg := range strings.Split(normalized, "/") {
+		if seg == "" {
+			continue
+		}
+		switch node.Kind {
+		case yaml.MappingNode:
+			key, value, ok := mappingEntry(node, seg)
+			if !ok {
+				return line
+			}
+			line = key.Line
+			node = value
+		case yaml.SequenceNode:
+			idx, err := strconv.Atoi(seg)
+			if err != nil || idx < 0 || idx >= len(node.Content) {
+				return line
+			}
+			node = node.Content[idx]
+			line = node.Line
+		default:
+			return line
+		}
+	}
+	return line
+}
+
+func mappingEntry(node *yaml.Node, key string) (*yaml.Node, *yaml.Node, bool) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i], node.Content[i+1], true
+		}
+	}
+	return nil, nil, false
+}
+
+func printLintResults(results []lintFileResult) {
+	passed := 0
+	for _, r := range results {
+		status := "FAIL"
+		if r.Pass {
+			status = "PASS"
+			passed++
+		}
+		fmt.Printf("%s  %s\n", status, r.Path)
+		for _, f := range r.Findings {
+			fmt.Printf("  %s:%d  %s: %s\n", f.Level, f.Line, f.Pointer, f.Message)
+		}
+	}
+	fmt.Printf("\n%d/%d file(s) passed\n", passed, len(results))
+}
+
+// findBestCandidate is one local schema file considered by
+// runLocalFindBest, with the docType/country its path implies.
+type findBestCandidate struct {
+	Path      string `json:"path"`
+	Country   string `json:"country,omitempty"`
+	MatchType string `json:"matchType"`
+}
+
+// runLocalFindBest reproduces find-best's ranking against a directory of
+// candidate schemas laid out docType/country/name.ext (or docType/name.ext
+// for schemas with no country), as bulk export/bundle export write. It
+// prefers an exact docType+country match, falling back to a docType-only
+// match the same way find-best's server-side ranking is documented to.
+// customerID is accepted for parity with `find-best`'s request shape, but
+// local files carry no per-customer metadata, so it has no effect on
+// ranking.
+func runLocalFindBest(root, docType, country, customerID string) error {
+	files, err := walkSchemaFiles(root)
+	if err != nil {
+		return err
+	}
+
+	var exact, docTypeOnly []findBestCandidate
+	for _, f := range files {
+		rel, err := filepath.Rel(root, f)
+		if err != nil {
+			continue
+		}
+		parts := strings.Split(filepath.ToSlash(rel), "/")
+		if len(parts) < 2 || parts[0] != docType {
+			continue
+		}
+
+		fileCountry := ""
+		if len(parts) >= 3 && parts[1] != "-" && parts[1] != "_" {
+			fileCountry = parts[1]
+		}
+
+		switch {
+		case country != "" && fileCountry == country:
+			exact = append(exact, findBestCandidate{Path: f, Country: fileCountry, MatchType: "docType+country"})
+		case fileCountry == "":
+			docTypeOnly = append(docTypeOnly, findBestCandidate{Path: f, MatchType: "docType-only"})
+		}
+	}
+
+	matches := exact
+	if len(matches) == 0 {
+		matches = docTypeOnly
+	}
+
+	if len(matches) == 0 {
+		if output.JSONOutput {
+			return output.PrintJSON(map[string]interface{}{"docType": docType, "country": country, "customerId": customerID, "match": nil})
+		}
+		return fmt.Errorf("no local candidate matched docType=%s country=%s under %s", docType, country, root)
+	}
+
+	best := matches[0]
+	if output.JSONOutput {
+		return output.PrintJSON(map[string]interface{}{
+			"docType":    docType,
+			"country":    country,
+			"customerId": customerID,
+			"match":      best,
+		})
+	}
+
+	fmt.Printf("Match type: %s\n", best.MatchType)
+	fmt.Printf("File:       %s\n", best.Path)
+	if customerID != "" {
+		fmt.Println("(--customer-id has no effect locally: local files carry no per-customer metadata)")
+	}
+	return nil
+}
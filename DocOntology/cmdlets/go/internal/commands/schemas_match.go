@@ -0,0 +1,187 @@
+package commands
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/batchmatch"
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var schemasMatchCmd = &cobra.Command{
+	Use:   "match <file|dir|glob>...",
+	Short: "Match one or more files to a schema",
+	Long:  "Upload one or more files (accepts individual paths, directories, and globs) to classify and find matching schemas. All files run concurrently across a worker pool of --parallel workers.",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		customerID, _ := cmd.Flags().GetString("customer-id")
+		parallel, _ := cmd.Flags().GetInt("parallel")
+		maxRetries, _ := cmd.Flags().GetInt("max-retries")
+		outputFormat, _ := cmd.Flags().GetString("output")
+
+		files, err := expandMatchTargets(args)
+		if err != nil {
+			return err
+		}
+		if len(files) == 0 {
+			return fmt.Errorf("no files matched")
+		}
+
+		var custIDPtr *string
+		if customerID != "" {
+			custIDPtr = &customerID
+		}
+
+		results := batchmatch.Run(cmd.Context(), GetClient(), files, batchmatch.Options{
+			Parallel:   parallel,
+			CustomerID: custIDPtr,
+			MaxRetries: maxRetries,
+		})
+
+		switch outputFormat {
+		case "ndjson":
+			return printMatchNDJSON(results)
+		case "csv":
+			return printMatchCSV(results)
+		default:
+			if output.JSONOutput {
+				return output.PrintJSON(results)
+			}
+			printMatchTable(results)
+			printMatchSummary(results)
+		}
+		return nil
+	},
+}
+
+func init() {
+	schemasCmd.AddCommand(schemasMatchCmd)
+
+	schemasMatchCmd.Flags().String("customer-id", "", "Customer ID for private schema matching")
+	schemasMatchCmd.Flags().Int("parallel", 4, "Number of files to classify concurrently")
+	schemasMatchCmd.Flags().Int("max-retries", 2, "Retries on transient (5xx) API errors, with exponential backoff")
+	schemasMatchCmd.Flags().String("output", "table", "Output format: table, csv, or ndjson")
+}
+
+// expandMatchTargets turns a mix of file paths, directories, and globs into
+// a flat, sorted list of regular files to classify.
+func expandMatchTargets(args []string) ([]string, error) {
+	var files []string
+	seen := map[string]bool{}
+
+	add := func(path string) {
+		if !seen[path] {
+			seen[path] = true
+			files = append(files, path)
+		}
+	}
+
+	for _, arg := range args {
+		matches, err := filepath.Glob(arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", arg, err)
+		}
+		if len(matches) == 0 {
+			matches = []string{arg}
+		}
+
+		for _, m := range matches {
+			info, err := os.Stat(m)
+			if err != nil {
+				return nil, fmt.Errorf("failed to stat %s: %w", m, err)
+			}
+			if !info.IsDir() {
+				add(m)
+				continue
+			}
+			entries, err := os.ReadDir(m)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read directory %s: %w", m, err)
+			}
+			for _, e := range entries {
+				if !e.IsDir() {
+					add(filepath.Join(m, e.Name()))
+				}
+			}
+		}
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+func printMatchTable(results []batchmatch.Result) {
+	headers := []string{"FILE", "DOC TYPE", "COUNTRY", "SCHEMA ID", "DURATION", "ERROR"}
+	rows := make([][]string, len(results))
+	for i, r := range results {
+		rows[i] = []string{
+			filepath.Base(r.File),
+			output.Truncate(r.DocType, 20),
+			r.Country,
+			r.SchemaID,
+			r.Duration.Round(time.Millisecond).String(),
+			r.Err,
+		}
+	}
+	output.PrintTable(headers, rows)
+}
+
+func printMatchCSV(results []batchmatch.Result) error {
+	w := csv.NewWriter(os.Stdout)
+	defer w.Flush()
+
+	if err := w.Write([]string{"file", "docType", "country", "schemaId", "versionId", "durationMs", "error"}); err != nil {
+		return err
+	}
+	for _, r := range results {
+		if err := w.Write([]string{
+			r.File, r.DocType, r.Country, r.SchemaID, r.VersionID,
+			fmt.Sprintf("%d", r.Duration.Milliseconds()), r.Err,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func printMatchNDJSON(results []batchmatch.Result) error {
+	enc := json.NewEncoder(os.Stdout)
+	for _, r := range results {
+		if err := enc.Encode(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func printMatchSummary(results []batchmatch.Result) {
+	counts := map[string]int{}
+	failed := 0
+	for _, r := range results {
+		if r.Err != "" {
+			failed++
+			continue
+		}
+		key := r.DocType
+		if key == "" {
+			key = "(unmatched)"
+		}
+		counts[key]++
+	}
+
+	fmt.Printf("\n%d file(s), %d failed\n", len(results), failed)
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Printf("  %-20s %d\n", k, counts[k])
+	}
+}
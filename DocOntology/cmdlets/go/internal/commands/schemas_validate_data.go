@@ -0,0 +1,180 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/output"
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/validate"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// validateDataReport is one data file's result against the schema, the
+// shape --json emits.
+type validateDataReport struct {
+	File      string              `json:"file"`
+	Valid     bool                `json:"valid"`
+	Errors    []validate.DataError `json:"errors,omitempty"`
+	Coercions []validate.Coercion  `json:"coercions,omitempty"`
+}
+
+var schemasValidateDataCmd = &cobra.Command{
+	Use:   "validate-data <schema-id>",
+	Short: "Validate sample data files against a schema's content, offline",
+	Long: `Fetch <schema-id>'s Content (or, with --against-file, read a local schema
+draft instead) and compile it once, then evaluate one or more data files
+against it entirely offline, without round-tripping through the extraction
+API — the workflow the extraction pipeline itself follows, adapted to this
+registry.
+
+Pass a single file with --data @sample.json, or a whole directory of
+.json/.yaml/.yml fixtures with --data-dir. Each failing field is reported
+with its full JSON Pointer path ("/invoice/lineItems/3/totalNet: expected
+number, got string"), followed by an aggregate pass/fail count. --json
+emits a structured {file, valid, errors:[{path, keyword, message}]} report
+per file, suitable for piping into a test harness.
+
+--against-file validates against a local schema draft instead of a
+registered one, useful before "schemas create". --coerce additionally
+reports (without mutating the data) every field where a string value
+doesn't match its schema type but a coercion the extraction pipeline
+applies — string->number, string->bool, a recognized date string->
+date-time — would fix it. --fail-fast stops at the first file with any
+validation error.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dataFlag, _ := cmd.Flags().GetString("data")
+		dataDir, _ := cmd.Flags().GetString("data-dir")
+		againstFile, _ := cmd.Flags().GetString("against-file")
+		coerce, _ := cmd.Flags().GetBool("coerce")
+		failFast, _ := cmd.Flags().GetBool("fail-fast")
+
+		if dataFlag == "" && dataDir == "" {
+			return fmt.Errorf("one of --data or --data-dir is required")
+		}
+		if dataFlag != "" && dataDir != "" {
+			return fmt.Errorf("--data and --data-dir are mutually exclusive")
+		}
+
+		var content map[string]interface{}
+		if againstFile != "" {
+			c, err := parseContent(againstFile)
+			if err != nil {
+				return fmt.Errorf("invalid --against-file: %w", err)
+			}
+			content = c
+		} else {
+			schema, err := GetClient().GetSchema(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			content = schema.Content
+		}
+
+		compiled, err := validate.Compile(content)
+		if err != nil {
+			return err
+		}
+
+		files, err := dataFiles(dataFlag, dataDir)
+		if err != nil {
+			return err
+		}
+
+		var reports []validateDataReport
+		failed := 0
+		for _, file := range files {
+			report, err := validateDataFile(compiled, content, file, coerce)
+			if err != nil {
+				return err
+			}
+			if !report.Valid {
+				failed++
+			}
+			reports = append(reports, report)
+			if failFast && !report.Valid {
+				break
+			}
+		}
+
+		if output.JSONOutput {
+			return output.PrintJSON(reports)
+		}
+		printValidateDataReports(reports)
+
+		if failed > 0 {
+			return fmt.Errorf("%d of %d file(s) failed validation", failed, len(reports))
+		}
+		return nil
+	},
+}
+
+func init() {
+	schemasCmd.AddCommand(schemasValidateDataCmd)
+
+	schemasValidateDataCmd.Flags().String("data", "", "A single data file to validate, e.g. @sample.json")
+	schemasValidateDataCmd.Flags().String("data-dir", "", "A directory of .json/.yaml/.yml data files to validate")
+	schemasValidateDataCmd.Flags().String("against-file", "", "Validate against a local schema draft instead of a registered schema, e.g. @draft.json")
+	schemasValidateDataCmd.Flags().Bool("coerce", false, "Report (without mutating) fields a type coercion would fix")
+	schemasValidateDataCmd.Flags().Bool("fail-fast", false, "Stop at the first file with a validation error")
+}
+
+// dataFiles resolves --data/--data-dir into the list of files to validate,
+// reusing schemas lint's directory walk for --data-dir.
+func dataFiles(dataFlag, dataDir string) ([]string, error) {
+	if dataFlag != "" {
+		return []string{strings.TrimPrefix(dataFlag, "@")}, nil
+	}
+	return walkSchemaFiles(dataDir)
+}
+
+// validateDataFile validates one data file (JSON or YAML) against compiled,
+// optionally reporting the coercions --coerce asked for.
+func validateDataFile(compiled *validate.CompiledSchema, content map[string]interface{}, path string, coerce bool) (validateDataReport, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return validateDataReport{}, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var data interface{}
+	if err := yaml.Unmarshal(raw, &data); err != nil {
+		return validateDataReport{
+			File:   path,
+			Errors: []validate.DataError{{Message: fmt.Sprintf("failed to parse %s: %s", path, err)}},
+		}, nil
+	}
+
+	errs, err := compiled.Validate(data)
+	if err != nil {
+		return validateDataReport{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	report := validateDataReport{File: path, Valid: len(errs) == 0, Errors: errs}
+	if coerce {
+		report.Coercions = validate.Coerce(content, data)
+	}
+	return report, nil
+}
+
+// printValidateDataReports prints a per-file PASS/FAIL line followed by its
+// findings, then an aggregate pass count.
+func printValidateDataReports(reports []validateDataReport) {
+	passed := 0
+	for _, r := range reports {
+		status := "FAIL"
+		if r.Valid {
+			status = "PASS"
+			passed++
+		}
+		fmt.Printf("%s  %s\n", status, r.File)
+		for _, e := range r.Errors {
+			fmt.Printf("  %s\n", e.String())
+		}
+		for _, c := range r.Coercions {
+			fmt.Printf("  coerce %s: %s -> %s\n", c.Pointer, c.From, c.To)
+		}
+	}
+	fmt.Printf("\n%d/%d file(s) passed\n", passed, len(reports))
+}
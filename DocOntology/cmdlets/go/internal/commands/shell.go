@@ -1,51 +1,96 @@
 package commands
 
 import (
-	"bufio"
+	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
 	"strconv"
 	"strings"
 
+	"github.com/chzyer/readline"
 	"github.com/miguel-bandeira-infosistema/schemactl/internal/client"
 	"github.com/miguel-bandeira-infosistema/schemactl/internal/config"
 	"github.com/miguel-bandeira-infosistema/schemactl/internal/output"
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/query"
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/watch"
 	"github.com/spf13/cobra"
 )
 
 var shellCmd = &cobra.Command{
 	Use:   "shell",
 	Short: "Start interactive shell",
-	Long:  "Start an interactive shell session where you can run commands without the 'schemactl' prefix",
+	Long:  "Start an interactive shell session where you can run commands without the 'schemactl' prefix. Pass --script to run a batch of commands non-interactively instead.",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		script, _ := cmd.Flags().GetString("script")
+		if script != "" {
+			return RunScript(script)
+		}
 		return RunShell()
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(shellCmd)
+	shellCmd.Flags().String("script", "", "Run newline-separated commands from a file (or stdin, with '-') instead of starting an interactive session")
+}
+
+// shellHistoryPath returns where the shell's persistent readline history is
+// stored, creating its parent directory if needed.
+func shellHistoryPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	dir := filepath.Join(home, ".schemactl")
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "history")
 }
 
 // RunShell starts the interactive shell
 func RunShell() error {
-	reader := bufio.NewReader(os.Stdin)
+	rl, err := readline.NewEx(&readline.Config{
+		Prompt:          shellPrompt(),
+		HistoryFile:     shellHistoryPath(),
+		AutoComplete:    newShellCompleter(),
+		InterruptPrompt: "^C",
+		EOFPrompt:       "exit",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to start interactive shell: %w", err)
+	}
+	defer rl.Close()
+
+	// Highlight JSON output in the interactive shell; runShellLine disables
+	// this around piped/redirected commands so `| jq` and `> file` still
+	// see plain, parseable JSON.
+	output.ColorJSON = true
+	defer func() { output.ColorJSON = false }()
 
 	printBanner()
 	fmt.Println()
 
 	for {
-		fmt.Print("schemactl> ")
-		input, err := reader.ReadString('\n')
+		rl.SetPrompt(shellPrompt())
+		line, err := rl.Readline()
 		if err != nil {
+			if err == readline.ErrInterrupt {
+				continue
+			}
 			if err == io.EOF {
-				fmt.Println("\nGoodbye!")
+				fmt.Println("Goodbye!")
 				return nil
 			}
 			return err
 		}
 
-		input = strings.TrimSpace(input)
+		input := strings.TrimSpace(line)
 		if input == "" {
 			continue
 		}
@@ -62,25 +107,88 @@ func RunShell() error {
 			continue
 		}
 
+		// A trailing "<<DELIM" reads a multi-line heredoc body from
+		// subsequent lines, so e.g. `schemas create --content <<EOF` can
+		// be followed by a pasted JSON document and a closing EOF line.
+		input, err = readHeredocIfPresent(rl, input)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			continue
+		}
+
 		// Parse input into args
 		args := parseArgs(input)
 		if len(args) == 0 {
 			continue
 		}
 
-		// Execute the command
-		executeShellCommand(args)
+		// Execute the command, routing its output through a pipe or file
+		// redirect if one was given.
+		runShellLine(args)
 	}
 }
 
-// parseArgs splits input string into arguments, respecting quotes
+// readHeredocIfPresent expands a "<<DELIM" marker anywhere in input into a
+// single quoted argument containing every line read from rl up to (but not
+// including) a line that's exactly DELIM.
+func readHeredocIfPresent(rl *readline.Instance, input string) (string, error) {
+	idx := strings.Index(input, "<<")
+	if idx == -1 {
+		return input, nil
+	}
+
+	rest := strings.TrimSpace(input[idx+2:])
+	delim := rest
+	if sp := strings.IndexAny(rest, " \t"); sp != -1 {
+		delim = rest[:sp]
+	}
+	delim = strings.Trim(delim, `'"`)
+	if delim == "" {
+		return input, nil
+	}
+
+	var body strings.Builder
+	for {
+		line, err := rl.Readline()
+		if err != nil {
+			return "", fmt.Errorf("unterminated heredoc (expected a line with just %q): %w", delim, err)
+		}
+		if strings.TrimRight(line, "\r") == delim {
+			break
+		}
+		body.WriteString(line)
+		body.WriteString("\n")
+	}
+
+	quoted := "'" + strings.ReplaceAll(body.String(), "'", `'\''`) + "'"
+	return input[:idx] + quoted, nil
+}
+
+// literalDollar stands in for a '$' inside single quotes while parseArgs
+// runs os.Expand, so that '$' is not expanded.
+const literalDollar = '\x00'
+
+// parseArgs splits input string into arguments, respecting quotes and
+// backslash escapes, and expanding $VAR/${VAR} environment references
+// outside of single quotes.
 func parseArgs(input string) []string {
 	var args []string
 	var current strings.Builder
 	inQuote := false
 	quoteChar := rune(0)
+	hasContent := false
+
+	runes := []rune(input)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		if r == '\\' && quoteChar != '\'' && i+1 < len(runes) {
+			i++
+			current.WriteRune(runes[i])
+			hasContent = true
+			continue
+		}
 
-	for _, r := range input {
 		switch {
 		case r == '"' || r == '\'':
 			if inQuote && r == quoteChar {
@@ -92,29 +200,168 @@ func parseArgs(input string) []string {
 			} else {
 				current.WriteRune(r)
 			}
+			hasContent = true
 		case r == ' ' && !inQuote:
-			if current.Len() > 0 {
+			if hasContent {
 				args = append(args, current.String())
 				current.Reset()
+				hasContent = false
 			}
+		case r == '$' && quoteChar == '\'':
+			// Kept out of os.Expand below, then restored to '$'.
+			current.WriteRune(literalDollar)
+			hasContent = true
 		default:
 			current.WriteRune(r)
+			hasContent = true
 		}
 	}
 
-	if current.Len() > 0 {
+	if hasContent {
 		args = append(args, current.String())
 	}
 
+	for i, a := range args {
+		args[i] = strings.ReplaceAll(os.Expand(a, os.Getenv), string(literalDollar), "$")
+	}
+
 	return args
 }
 
-// executeShellCommand runs a command with the given args
+// runShellLine dispatches args, splitting off a trailing "| <command>" or
+// "> <file>" so a command's output can be filtered through an external
+// program or saved to disk without leaving the shell.
+func runShellLine(args []string) {
+	cmdArgs, sink := splitSink(args)
+	if len(cmdArgs) == 0 {
+		fmt.Println("Error: empty command before redirect")
+		return
+	}
+
+	if sink == nil {
+		executeShellCommand(cmdArgs)
+		return
+	}
+
+	// Piped/redirected output must stay plain so `| jq` and `> file` see
+	// parseable JSON rather than ANSI color codes.
+	wasColor := output.ColorJSON
+	output.ColorJSON = false
+	defer func() { output.ColorJSON = wasColor }()
+
+	out, err := captureShellCommand(cmdArgs)
+	if err != nil {
+		fmt.Print(out)
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	switch sink.kind {
+	case sinkPipe:
+		if err := pipeTo(sink.target, out); err != nil {
+			fmt.Printf("Error: %v\n", err)
+		}
+	case sinkFile:
+		if err := os.WriteFile(sink.target, []byte(out), 0o644); err != nil {
+			fmt.Printf("Error: %v\n", err)
+		} else {
+			fmt.Printf("Wrote output to %s\n", sink.target)
+		}
+	}
+	fmt.Println()
+}
+
+type sinkKind int
+
+const (
+	sinkPipe sinkKind = iota
+	sinkFile
+)
+
+// shellSink is a trailing "| command" or "> file" found at the end of a
+// shell line.
+type shellSink struct {
+	kind   sinkKind
+	target string
+}
+
+// splitSink looks for a "|" or ">" token and splits the line into the
+// schemactl args to run and where to send their output. It sees args after
+// parseArgs has removed quotes, so a quoted "|" or ">" argument splits the
+// line as well.
+func splitSink(args []string) ([]string, *shellSink) {
+	for i, a := range args {
+		switch a {
+		case "|":
+			if i+1 < len(args) {
+				return args[:i], &shellSink{kind: sinkPipe, target: strings.Join(args[i+1:], " ")}
+			}
+			return args[:i], nil
+		case ">":
+			if i+1 < len(args) {
+				return args[:i], &shellSink{kind: sinkFile, target: args[i+1]}
+			}
+			return args[:i], nil
+		}
+	}
+	return args, nil
+}
+
+// pipeTo runs shellLine as an external command, feeding it input on stdin
+// and connecting its stdout/stderr to the shell's.
+func pipeTo(shellLine string, input string) error {
+	parts := parseArgs(shellLine)
+	if len(parts) == 0 {
+		return fmt.Errorf("empty pipeline command")
+	}
+
+	c := exec.Command(parts[0], parts[1:]...)
+	c.Stdin = strings.NewReader(input)
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	return c.Run()
+}
+
+// captureShellCommand runs args like executeShellCommand, but captures its
+// stdout instead of writing it directly to the terminal, so it can be piped
+// or redirected.
+func captureShellCommand(args []string) (string, error) {
+	origStdout := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		return "", err
+	}
+	os.Stdout = w
+
+	cmd := buildRootCommand()
+	cmd.SetArgs(args)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	runErr := cmd.ExecuteContext(ctx)
+	stop()
+
+	w.Close()
+	os.Stdout = origStdout
+
+	var buf bytes.Buffer
+	io.Copy(&buf, r)
+
+	return buf.String(), runErr
+}
+
+// executeShellCommand runs a command with the given args. Each command gets
+// its own context cancelled on SIGINT, so Ctrl-C during a long-running
+// `--watch` interrupts just that command and returns to the prompt instead
+// of killing the shell.
 func executeShellCommand(args []string) {
 	// Create a fresh command tree for each execution
 	cmd := buildRootCommand()
 	cmd.SetArgs(args)
-	if err := cmd.Execute(); err != nil {
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := cmd.ExecuteContext(ctx); err != nil {
 		fmt.Printf("Error: %v\n", err)
 	}
 	fmt.Println()
@@ -137,7 +384,8 @@ func buildRootCommand() *cobra.Command {
 	// Add global flags
 	cmd.PersistentFlags().StringVar(&apiURL, "api-url", "", "API base URL")
 	cmd.PersistentFlags().StringVar(&apiKey, "api-key", "", "API key")
-	cmd.PersistentFlags().BoolVar(&jsonOut, "json", false, "Output as JSON")
+	cmd.PersistentFlags().BoolVar(&jsonOut, "json", false, "Output as JSON (deprecated, use --output json)")
+	cmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "table", "Output format: table, json, jsonl, yaml, csv, tsv, or template")
 
 	// Add all commands
 	cmd.AddCommand(buildVersionCmd())
@@ -161,32 +409,40 @@ func buildVersionCmd() *cobra.Command {
 }
 
 func buildHealthCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "health",
 		Short: "Check API health",
 		Long:  "Check the health status of the API server and database connection",
 		RunE: func(c *cobra.Command, args []string) error {
-			health, err := GetClient().Health()
-			if err != nil {
-				return err
-			}
-			if output.JSONOutput {
-				return output.PrintJSON(health)
-			}
-			statusIcon := "OK"
-			if health.Status != "ok" {
-				statusIcon = "ERROR"
+			fetch := func() error {
+				health, err := GetClient().Health(c.Context())
+				if err != nil {
+					return err
+				}
+				if output.JSONOutput {
+					return output.PrintJSON(health)
+				}
+				statusIcon := "OK"
+				if health.Status != "ok" {
+					statusIcon = "ERROR"
+				}
+				dbIcon := "Connected"
+				if health.Database != "connected" {
+					dbIcon = "Disconnected"
+				}
+				fmt.Printf("Status:    %s\n", statusIcon)
+				fmt.Printf("Database:  %s\n", dbIcon)
+				fmt.Printf("Timestamp: %s\n", health.Timestamp.Format("2006-01-02 15:04:05"))
+				return nil
 			}
-			dbIcon := "Connected"
-			if health.Database != "connected" {
-				dbIcon = "Disconnected"
+			if watchFlag, _ := c.Flags().GetBool("watch"); watchFlag {
+				return watch.Run(c.Context(), watchInterval(c), fetch)
 			}
-			fmt.Printf("Status:    %s\n", statusIcon)
-			fmt.Printf("Database:  %s\n", dbIcon)
-			fmt.Printf("Timestamp: %s\n", health.Timestamp.Format("2006-01-02 15:04:05"))
-			return nil
+			return fetch()
 		},
 	}
+	addWatchFlags(cmd)
+	return cmd
 }
 
 func buildSchemasCmd() *cobra.Command {
@@ -221,7 +477,7 @@ func buildSchemasCmd() *cobra.Command {
 			}
 			opts.Limit, _ = c.Flags().GetInt("limit")
 			opts.Offset, _ = c.Flags().GetInt("offset")
-			result, err := GetClient().ListSchemas(opts)
+			result, err := GetClient().ListSchemas(c.Context(), opts)
 			if err != nil {
 				return err
 			}
@@ -265,17 +521,24 @@ func buildSchemasCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(c *cobra.Command, args []string) error {
 			id := args[0]
-			schema, err := GetClient().GetSchema(id)
-			if err != nil {
-				return err
+			fetch := func() error {
+				schema, err := GetClient().GetSchema(c.Context(), id)
+				if err != nil {
+					return err
+				}
+				if output.JSONOutput {
+					return output.PrintJSON(schema)
+				}
+				printSchemaDetails(schema)
+				return nil
 			}
-			if output.JSONOutput {
-				return output.PrintJSON(schema)
+			if watchFlag, _ := c.Flags().GetBool("watch"); watchFlag {
+				return watch.Run(c.Context(), watchInterval(c), fetch)
 			}
-			printSchemaDetails(schema)
-			return nil
+			return fetch()
 		},
 	}
+	addWatchFlags(getCmd)
 
 	createCmd := &cobra.Command{
 		Use:   "create",
@@ -316,7 +579,7 @@ func buildSchemasCmd() *cobra.Command {
 			if customerID != "" {
 				req.CustomerID = &customerID
 			}
-			schema, err := GetClient().CreateSchema(req)
+			schema, err := GetClient().CreateSchema(c.Context(), req)
 			if err != nil {
 				return err
 			}
@@ -385,7 +648,7 @@ func buildSchemasCmd() *cobra.Command {
 			if !hasUpdate {
 				return fmt.Errorf("no update fields provided")
 			}
-			schema, err := GetClient().UpdateSchema(id, req)
+			schema, err := GetClient().UpdateSchema(c.Context(), id, req)
 			if err != nil {
 				return err
 			}
@@ -411,7 +674,7 @@ func buildSchemasCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(c *cobra.Command, args []string) error {
 			id := args[0]
-			schema, err := GetClient().ActivateSchema(id)
+			schema, err := GetClient().ActivateSchema(c.Context(), id)
 			if err != nil {
 				return err
 			}
@@ -430,7 +693,7 @@ func buildSchemasCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(c *cobra.Command, args []string) error {
 			id := args[0]
-			schema, err := GetClient().DeprecateSchema(id)
+			schema, err := GetClient().DeprecateSchema(c.Context(), id)
 			if err != nil {
 				return err
 			}
@@ -449,7 +712,7 @@ func buildSchemasCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(c *cobra.Command, args []string) error {
 			id := args[0]
-			if err := GetClient().DeleteSchema(id); err != nil {
+			if err := GetClient().DeleteSchema(c.Context(), id); err != nil {
 				return err
 			}
 			output.PrintSuccess(fmt.Sprintf("Schema deleted: %s", id))
@@ -457,6 +720,38 @@ func buildSchemasCmd() *cobra.Command {
 		},
 	}
 
+	summaryCmd := &cobra.Command{
+		Use:   "summary <id>",
+		Short: "Print a schema's content-addressed SHA-256 summary",
+		Long:  "Compute the SHA-256 summary of a schema's content (publicId or publicVersionId), the same digest sent as X-Schema-Summary on create/update.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			summary, err := GetClient().GetSchemaSummary(c.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			if output.JSONOutput {
+				return output.PrintJSON(map[string]string{"publicId": args[0], "summary": summary})
+			}
+			fmt.Println(summary)
+			return nil
+		},
+	}
+
+	verifyCmd := &cobra.Command{
+		Use:   "verify <id>",
+		Short: "Verify a schema's stored summary matches its content",
+		Long:  "Fetch a schema (publicId or publicVersionId), recompute its content summary, and compare it against the X-Schema-Summary header the server returns alongside it, reporting any mismatch.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := GetClient().VerifySchema(c.Context(), args[0]); err != nil {
+				return err
+			}
+			output.PrintSuccess(fmt.Sprintf("Schema summary verified: %s", args[0]))
+			return nil
+		},
+	}
+
 	findBestCmd := &cobra.Command{
 		Use:   "find-best",
 		Short: "Find best matching schema",
@@ -474,7 +769,7 @@ func buildSchemasCmd() *cobra.Command {
 			if customerID != "" {
 				req.CustomerID = &customerID
 			}
-			result, err := GetClient().FindBestSchema(req)
+			result, err := GetClient().FindBestSchema(c.Context(), req)
 			if err != nil {
 				return err
 			}
@@ -502,27 +797,34 @@ func buildSchemasCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(c *cobra.Command, args []string) error {
 			id := args[0]
-			versions, err := GetClient().GetSchemaVersions(id)
-			if err != nil {
-				return err
-			}
-			if output.JSONOutput {
-				return output.PrintJSON(versions)
-			}
-			headers := []string{"VERSION ID", "VERSION", "STATUS", "CREATED AT"}
-			rows := make([][]string, len(versions))
-			for i, v := range versions {
-				rows[i] = []string{
-					v.PublicVersionID,
-					strconv.Itoa(v.Version),
-					string(v.Status),
-					v.CreatedAt.Format("2006-01-02 15:04:05"),
+			fetch := func() error {
+				versions, err := GetClient().GetSchemaVersions(c.Context(), id)
+				if err != nil {
+					return err
+				}
+				if output.JSONOutput {
+					return output.PrintJSON(versions)
+				}
+				headers := []string{"VERSION ID", "VERSION", "STATUS", "CREATED AT"}
+				rows := make([][]string, len(versions))
+				for i, v := range versions {
+					rows[i] = []string{
+						v.PublicVersionID,
+						strconv.Itoa(v.Version),
+						string(v.Status),
+						v.CreatedAt.Format("2006-01-02 15:04:05"),
+					}
 				}
+				output.PrintTable(headers, rows)
+				return nil
 			}
-			output.PrintTable(headers, rows)
-			return nil
+			if watchFlag, _ := c.Flags().GetBool("watch"); watchFlag {
+				return watch.Run(c.Context(), watchInterval(c), fetch)
+			}
+			return fetch()
 		},
 	}
+	addWatchFlags(versionsCmd)
 
 	matchCmd := &cobra.Command{
 		Use:   "match <file>",
@@ -536,7 +838,7 @@ func buildSchemasCmd() *cobra.Command {
 			if customerID != "" {
 				custIDPtr = &customerID
 			}
-			result, err := GetClient().MatchSchema(filePath, custIDPtr)
+			result, err := GetClient().MatchSchema(c.Context(), filePath, custIDPtr, output.NewProgressFunc(filepath.Base(filePath)))
 			if err != nil {
 				return err
 			}
@@ -562,10 +864,16 @@ func buildSchemasCmd() *cobra.Command {
 	}
 	matchCmd.Flags().String("customer-id", "", "Customer ID for private schema matching")
 
-	cmd.AddCommand(listCmd, getCmd, createCmd, updateCmd, activateCmd, deprecateCmd, deleteCmd, findBestCmd, versionsCmd, matchCmd)
+	cmd.AddCommand(listCmd, getCmd, createCmd, updateCmd, activateCmd, deprecateCmd, deleteCmd, summaryCmd, verifyCmd, findBestCmd, versionsCmd, matchCmd)
 	return cmd
 }
 
+// buildDocTypesCmd builds the shell's doc-types command tree. It delegates
+// to the same helpers as docTypesCmd in doctypes.go (resolveDocTypeCode,
+// applyDocTypeFilter, runDocTypePatch, listAllSchemasByDocType) rather than
+// re-deriving their logic, so the shell can't drift out of sync with the
+// real command's flags (--filter/--sort, --patch, --hard/--cascade/--dry-run)
+// the way hand-duplicated RunE bodies did before.
 func buildDocTypesCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "doc-types",
@@ -577,13 +885,40 @@ func buildDocTypesCmd() *cobra.Command {
 	listCmd := &cobra.Command{
 		Use:   "list",
 		Short: "List document types",
-		Long:  "List all document types. Use --all to include inactive ones.",
+		Long:  "List all document types. Use --all to include inactive ones. --filter/--sort accept the same syntax as `schemactl doc-types list`.",
 		RunE: func(c *cobra.Command, args []string) error {
 			includeAll, _ := c.Flags().GetBool("all")
-			docTypes, err := GetClient().ListDocTypes(includeAll)
+			filterFlag, _ := c.Flags().GetString("filter")
+			sortFlag, _ := c.Flags().GetString("sort")
+			clientFilter, _ := c.Flags().GetBool("client-filter")
+
+			filter, err := query.ParseFilter(filterFlag)
 			if err != nil {
 				return err
 			}
+			sortSpec, err := query.ParseSort(sortFlag)
+			if err != nil {
+				return err
+			}
+
+			var docTypes []client.DocType
+			if clientFilter {
+				docTypes, err = GetClient().ListDocTypes(c.Context(), includeAll)
+				if err != nil {
+					return err
+				}
+				docTypes, err = applyDocTypeFilter(docTypes, filter, sortSpec)
+				if err != nil {
+					return err
+				}
+			} else {
+				params := query.BuildParams(filter, output.Fields, sortSpec)
+				docTypes, err = GetClient().ListDocTypesFiltered(c.Context(), includeAll, params)
+				if err != nil {
+					return err
+				}
+			}
+
 			if output.JSONOutput {
 				return output.PrintJSON(docTypes)
 			}
@@ -597,20 +932,25 @@ func buildDocTypesCmd() *cobra.Command {
 					output.BoolString(dt.IsActive),
 				}
 			}
-			output.PrintTable(headers, rows)
-			return nil
+			return output.PrintRows(headers, rows)
 		},
 	}
 	listCmd.Flags().Bool("all", false, "Include inactive doc types")
+	listCmd.Flags().String("filter", "", "Filter predicate, e.g. 'name eq \"Invoice\"'")
+	listCmd.Flags().String("sort", "", "Sort spec, e.g. code:desc")
+	listCmd.Flags().Bool("client-filter", false, "Apply --filter/--sort locally instead of via query params")
 
 	getCmd := &cobra.Command{
-		Use:   "get <code>",
+		Use:   "get [code]",
 		Short: "Get a document type",
-		Long:  "Get a document type by its code",
-		Args:  cobra.ExactArgs(1),
+		Long:  "Get a document type by its code. If code is omitted and prompting is available, a searchable list is shown to pick from.",
+		Args:  cobra.MaximumNArgs(1),
 		RunE: func(c *cobra.Command, args []string) error {
-			code := args[0]
-			docType, err := GetClient().GetDocType(code)
+			code, err := resolveDocTypeCode(c, args)
+			if err != nil {
+				return err
+			}
+			docType, err := GetClient().GetDocType(c.Context(), code)
 			if err != nil {
 				return err
 			}
@@ -643,7 +983,7 @@ func buildDocTypesCmd() *cobra.Command {
 			if description != "" {
 				req.Description = &description
 			}
-			docType, err := GetClient().CreateDocType(req)
+			docType, err := GetClient().CreateDocType(c.Context(), req)
 			if err != nil {
 				return err
 			}
@@ -657,12 +997,20 @@ func buildDocTypesCmd() *cobra.Command {
 	createCmd.Flags().StringP("description", "d", "", "Doc type description")
 
 	updateCmd := &cobra.Command{
-		Use:   "update <code>",
+		Use:   "update [code]",
 		Short: "Update a document type",
-		Long:  "Update a document type's name, description, or active status",
-		Args:  cobra.ExactArgs(1),
+		Long:  "Update a document type's name, description, or active status. If code is omitted and prompting is available, a searchable list is shown to pick from. --patch accepts an RFC 6902 JSON Patch document, the same as `schemactl doc-types update --patch`.",
+		Args:  cobra.MaximumNArgs(1),
 		RunE: func(c *cobra.Command, args []string) error {
-			code := args[0]
+			code, err := resolveDocTypeCode(c, args)
+			if err != nil {
+				return err
+			}
+
+			if patchFlag, _ := c.Flags().GetString("patch"); patchFlag != "" {
+				return runDocTypePatch(c, code, patchFlag)
+			}
+
 			req := &client.UpdateDocTypeRequest{}
 			hasUpdate := false
 			if name, _ := c.Flags().GetString("name"); name != "" {
@@ -682,7 +1030,7 @@ func buildDocTypesCmd() *cobra.Command {
 			if !hasUpdate {
 				return fmt.Errorf("no update fields provided")
 			}
-			docType, err := GetClient().UpdateDocType(code, req)
+			docType, err := GetClient().UpdateDocType(c.Context(), code, req)
 			if err != nil {
 				return err
 			}
@@ -696,21 +1044,94 @@ func buildDocTypesCmd() *cobra.Command {
 	updateCmd.Flags().StringP("name", "n", "", "New name")
 	updateCmd.Flags().StringP("description", "d", "", "New description")
 	updateCmd.Flags().Bool("active", true, "Set active status")
+	updateCmd.Flags().String("patch", "", "RFC 6902 JSON Patch document (inline JSON, @file.json, or - for stdin)")
 
 	deleteCmd := &cobra.Command{
-		Use:   "delete <code>",
+		Use:   "delete [code]",
 		Short: "Delete a document type",
-		Long:  "Soft delete a document type (sets isActive to false)",
-		Args:  cobra.ExactArgs(1),
+		Long:  "Soft delete a document type by default (sets isActive to false). --hard permanently deletes it instead, refusing if any non-deprecated schema still references it unless --cascade is also given. --dry-run prints what would happen without deleting anything. Matches `schemactl doc-types delete`.",
+		Args:  cobra.MaximumNArgs(1),
 		RunE: func(c *cobra.Command, args []string) error {
-			code := args[0]
-			if err := GetClient().DeleteDocType(code); err != nil {
+			code, err := resolveDocTypeCode(c, args)
+			if err != nil {
+				return err
+			}
+			hard, _ := c.Flags().GetBool("hard")
+			cascade, _ := c.Flags().GetBool("cascade")
+			dryRun, _ := c.Flags().GetBool("dry-run")
+			yes, _ := c.Flags().GetBool("yes")
+
+			if cascade && !hard {
+				return fmt.Errorf("--cascade only applies to --hard delete")
+			}
+
+			dependents, err := listAllSchemasByDocType(c.Context(), code)
+			if err != nil {
+				return err
+			}
+
+			var active []client.SchemaWithRelations
+			for _, s := range dependents {
+				if s.Status != client.StatusDeprecated {
+					active = append(active, s)
+				}
+			}
+
+			if len(dependents) > 0 {
+				fmt.Printf("Doc type %s is referenced by %d schema(s):\n", code, len(dependents))
+				for _, s := range dependents {
+					fmt.Printf("  - %s (%s, status: %s)\n", s.PublicID, s.Name, s.Status)
+				}
+			}
+
+			if hard && len(active) > 0 && !cascade {
+				return fmt.Errorf("doc type %s has %d schema(s) that aren't deprecated; pass --cascade to deprecate them first, or delete without --hard", code, len(active))
+			}
+
+			if dryRun {
+				verb := "soft-delete"
+				if hard {
+					verb = "hard-delete"
+				}
+				msg := fmt.Sprintf("Dry run: would %s doc type %s", verb, code)
+				if hard && cascade && len(active) > 0 {
+					msg += fmt.Sprintf(" (after deprecating %d referencing schema(s))", len(active))
+				}
+				output.PrintSuccess(msg)
+				return nil
+			}
+
+			if !yes && !output.Confirm(fmt.Sprintf("Delete doc type %s?", code)) {
+				return fmt.Errorf("delete cancelled")
+			}
+
+			if hard && cascade {
+				for _, s := range active {
+					if _, err := GetClient().DeprecateSchema(c.Context(), s.PublicID); err != nil {
+						return fmt.Errorf("deprecating schema %s: %w", s.PublicID, err)
+					}
+				}
+			}
+
+			if hard {
+				if err := GetClient().HardDeleteDocType(c.Context(), code); err != nil {
+					return err
+				}
+				output.PrintSuccess(fmt.Sprintf("Doc type permanently deleted: %s", code))
+				return nil
+			}
+
+			if err := GetClient().DeleteDocType(c.Context(), code); err != nil {
 				return err
 			}
 			output.PrintSuccess(fmt.Sprintf("Doc type deleted: %s", code))
 			return nil
 		},
 	}
+	deleteCmd.Flags().Bool("hard", false, "Permanently delete instead of soft-deleting (refuses if non-deprecated schemas reference the doc type, unless --cascade)")
+	deleteCmd.Flags().Bool("cascade", false, "Also deprecate schemas referencing the doc type before --hard deleting it")
+	deleteCmd.Flags().Bool("dry-run", false, "Print the dependency graph and what would be deleted, without deleting anything")
+	deleteCmd.Flags().Bool("yes", false, "Delete without prompting for confirmation")
 
 	cmd.AddCommand(listCmd, getCmd, createCmd, updateCmd, deleteCmd)
 	return cmd
@@ -729,7 +1150,7 @@ func buildCountriesCmd() *cobra.Command {
 		Long:  "List all countries. Use --all to include inactive ones.",
 		RunE: func(c *cobra.Command, args []string) error {
 			includeAll, _ := c.Flags().GetBool("all")
-			countries, err := GetClient().ListCountries(includeAll)
+			countries, err := GetClient().ListCountries(c.Context(), includeAll)
 			if err != nil {
 				return err
 			}
@@ -758,7 +1179,7 @@ func buildCountriesCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(c *cobra.Command, args []string) error {
 			code := args[0]
-			country, err := GetClient().GetCountry(code)
+			country, err := GetClient().GetCountry(c.Context(), code)
 			if err != nil {
 				return err
 			}
@@ -786,7 +1207,7 @@ func buildCountriesCmd() *cobra.Command {
 				Code: code,
 				Name: name,
 			}
-			country, err := GetClient().CreateCountry(req)
+			country, err := GetClient().CreateCountry(c.Context(), req)
 			if err != nil {
 				return err
 			}
@@ -819,7 +1240,7 @@ func buildCountriesCmd() *cobra.Command {
 			if !hasUpdate {
 				return fmt.Errorf("no update fields provided")
 			}
-			country, err := GetClient().UpdateCountry(code, req)
+			country, err := GetClient().UpdateCountry(c.Context(), code, req)
 			if err != nil {
 				return err
 			}
@@ -840,7 +1261,7 @@ func buildCountriesCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(c *cobra.Command, args []string) error {
 			code := args[0]
-			if err := GetClient().DeleteCountry(code); err != nil {
+			if err := GetClient().DeleteCountry(c.Context(), code); err != nil {
 				return err
 			}
 			output.PrintSuccess(fmt.Sprintf("Country deleted: %s", code))
@@ -859,7 +1280,7 @@ func buildReferenceDataCmd() *cobra.Command {
 		Short:   "Get all reference data",
 		Long:    "Get all active doc types and countries in a single request",
 		RunE: func(c *cobra.Command, args []string) error {
-			data, err := GetClient().GetReferenceData()
+			data, err := GetClient().GetReferenceData(c.Context())
 			if err != nil {
 				return err
 			}
@@ -898,17 +1319,43 @@ func buildReferenceDataCmd() *cobra.Command {
 // initClient initializes the API client (called in shell mode)
 func initClient() error {
 	var err error
-	cfg, err = config.Load()
+	cfg, err = config.Load(contextName)
 	if err != nil {
 		return err
 	}
-	cfg.WithAPIURL(apiURL).WithAPIKey(apiKey)
+	cfg.WithAPIURL(apiURL)
+	if err := cfg.SetAPIKey(apiKey); err != nil {
+		return err
+	}
+	cfg.WithAPISocketPath(apiSocket)
+	cfg.WithClientTLS(clientCert, clientKey, caCert)
+	if insecureSkipVerify {
+		cfg.InsecureSkipVerify = insecureSkipVerify
+	}
 	if err := cfg.Validate(); err != nil {
 		return err
 	}
-	output.JSONOutput = jsonOut
-	apiClient = client.New(cfg)
-	return nil
+	format, err := output.ParseFormat(outputFormat)
+	if err != nil {
+		return err
+	}
+	if jsonOut {
+		format = output.FormatJSON
+	}
+	output.ActiveFormat = format
+	output.JSONOutput = format == output.FormatJSON || format == output.FormatJSONL
+	apiClient, err = client.New(cfg)
+	return err
+}
+
+// shellPrompt returns the interactive prompt string, tagging it with the
+// active context name (if any) so it's obvious which environment commands
+// will hit — e.g. "schemactl[prod]> ".
+func shellPrompt() string {
+	if cfg != nil && cfg.ActiveContext != "" {
+		return fmt.Sprintf("schemactl[%s]> ", cfg.ActiveContext)
+	}
+	return "schemactl> "
 }
 
 // printBanner prints the welcome ASCII art banner
@@ -1029,6 +1476,21 @@ FLAGS (can be added to any command)
 
   --json              Output results as JSON
                       Example: schemas list --json
+
+TIPS
+────────────────────────────────────────────────────────────
+
+  Use Tab for completion (commands, flags, and live schema/doc-type/country
+  codes) and Up/Down or Ctrl-R to search command history.
+
+  Pipe or redirect a command's output:
+                      Example: schemas list --json | jq '.data[].publicId'
+                      Example: schemas list --json > schemas.json
+
+  Run a batch of commands non-interactively for CI pipelines:
+                      Example: schemactl shell --script commands.dd
+                      Script syntax: set VAR=value, capture VAR = <command>,
+                      if last-exit-code != 0 then <command>, set -e off
 `
 	fmt.Println(help)
 }
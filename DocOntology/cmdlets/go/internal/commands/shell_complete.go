@@ -0,0 +1,155 @@
+package commands
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/chzyer/readline"
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/client"
+	"github.com/spf13/cobra"
+)
+
+// completionCacheTTL bounds how long dynamic completion suggestions (schema
+// IDs, doc-type codes, country codes) are reused before being re-fetched
+// from the API, so tab completion doesn't make a request on every keystroke.
+const completionCacheTTL = 30 * time.Second
+
+// completionCache holds the live reference data used for shell tab
+// completion, refreshed lazily and shared across Readline invocations.
+type completionCache struct {
+	mu        sync.Mutex
+	fetchedAt time.Time
+	schemaIDs []string
+	docTypes  []string
+	countries []string
+}
+
+var shellCompletion completionCache
+
+func (c *completionCache) refresh() {
+	c.mu.Lock()
+	stale := time.Since(c.fetchedAt) > completionCacheTTL
+	c.mu.Unlock()
+	if !stale || apiClient == nil {
+		return
+	}
+
+	docTypes := fetchDocTypeCodes(apiClient)
+	countries := fetchCountryCodes(apiClient)
+	schemaIDs := fetchSchemaIDs(apiClient)
+
+	c.mu.Lock()
+	c.docTypes = docTypes
+	c.countries = countries
+	c.schemaIDs = schemaIDs
+	c.fetchedAt = time.Now()
+	c.mu.Unlock()
+}
+
+func fetchDocTypeCodes(c *client.Client) []string {
+	docTypes, err := c.ListDocTypes(context.Background(), false)
+	if err != nil {
+		return nil
+	}
+	codes := make([]string, len(docTypes))
+	for i, d := range docTypes {
+		codes[i] = d.Code
+	}
+	return codes
+}
+
+func fetchCountryCodes(c *client.Client) []string {
+	countries, err := c.ListCountries(context.Background(), false)
+	if err != nil {
+		return nil
+	}
+	codes := make([]string, len(countries))
+	for i, country := range countries {
+		codes[i] = country.Code
+	}
+	return codes
+}
+
+func fetchSchemaIDs(c *client.Client) []string {
+	list, err := c.ListSchemas(context.Background(), &client.ListSchemasOptions{Limit: 200})
+	if err != nil {
+		return nil
+	}
+	ids := make([]string, len(list.Data))
+	for i, s := range list.Data {
+		ids[i] = s.PublicID
+	}
+	return ids
+}
+
+func (c *completionCache) schemaCodes() func(string) []string {
+	return func(string) []string {
+		c.refresh()
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return append([]string(nil), c.schemaIDs...)
+	}
+}
+
+func (c *completionCache) docTypeCodes() func(string) []string {
+	return func(string) []string {
+		c.refresh()
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return append([]string(nil), c.docTypes...)
+	}
+}
+
+func (c *completionCache) countryCodes() func(string) []string {
+	return func(string) []string {
+		c.refresh()
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return append([]string(nil), c.countries...)
+	}
+}
+
+// newShellCompleter builds a readline completer from the live Cobra command
+// tree, augmented with dynamic suggestions (fed by shellCompletion) for the
+// arguments commands like `schemas get`, `doc-types get`, and `countries
+// get` expect.
+func newShellCompleter() *readline.PrefixCompleter {
+	return readline.NewPrefixCompleter(completerItems(buildRootCommand())...)
+}
+
+func completerItems(cmd *cobra.Command) []readline.PrefixCompleterInterface {
+	items := make([]readline.PrefixCompleterInterface, 0, len(cmd.Commands()))
+	for _, sub := range cmd.Commands() {
+		if sub.Hidden {
+			continue
+		}
+		children := completerItems(sub)
+		children = append(children, dynamicArgCompleters(cmd.Name(), sub.Name())...)
+		items = append(items, readline.PcItem(sub.Name(), children...))
+	}
+	return items
+}
+
+// dynamicArgCompleters returns dynamic completers for commands whose first
+// positional argument is a known reference-data code.
+func dynamicArgCompleters(parent, name string) []readline.PrefixCompleterInterface {
+	switch parent {
+	case "schemas":
+		switch name {
+		case "get", "update", "delete", "versions", "activate", "deprecate", "summary", "verify":
+			return []readline.PrefixCompleterInterface{readline.PcItemDynamic(shellCompletion.schemaCodes())}
+		}
+	case "doc-types":
+		switch name {
+		case "get", "update", "delete":
+			return []readline.PrefixCompleterInterface{readline.PcItemDynamic(shellCompletion.docTypeCodes())}
+		}
+	case "countries":
+		switch name {
+		case "get", "update", "delete":
+			return []readline.PrefixCompleterInterface{readline.PcItemDynamic(shellCompletion.countryCodes())}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// scriptSession tracks the state a batch script carries from line to line:
+// the exit code of the last command run, and whether a failing command
+// should abort the whole script (the default, overridable with `set -e off`).
+type scriptSession struct {
+	lastExitCode int
+	abortOnError bool
+}
+
+// RunScript executes newline-separated commands from path (or stdin when
+// path is "-"), supporting `set VAR=value`, `if last-exit-code <op> <n>
+// then <command>`, and `capture VAR = <command>`. It's the non-interactive
+// counterpart to RunShell, meant for CI pipelines and other automation that
+// shouldn't have to wrap each subcommand in bash.
+func RunScript(path string) error {
+	var r io.Reader
+	if path == "-" {
+		r = os.Stdin
+	} else {
+		f, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("failed to open script %s: %w", path, err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	session := &scriptSession{abortOnError: true}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if err := runScriptLine(session, line); err != nil {
+			if session.abortOnError {
+				return fmt.Errorf("line %d: %w", lineNum, err)
+			}
+			fmt.Fprintf(os.Stderr, "line %d: %v\n", lineNum, err)
+		}
+	}
+	return scanner.Err()
+}
+
+// scriptCaptureRe pulls a schema's publicId out of the plain-text success
+// message `schemas create`/`schemas update` print (e.g. "Schema created:
+// sch_abc123 (version: schv_def456)"), since that's the common case for
+// `capture VAR = schemas create ...` in a script.
+var scriptCaptureRe = regexp.MustCompile(`\b(sch_\S+|schv_\S+)\b`)
+
+// runScriptLine handles one non-empty, non-comment line of a script. It
+// recurses for the `then` clause of an `if` line, so `set`/`capture` also
+// work as conditional actions.
+func runScriptLine(session *scriptSession, line string) error {
+	switch {
+	case strings.HasPrefix(line, "set -e "):
+		switch strings.TrimSpace(strings.TrimPrefix(line, "set -e ")) {
+		case "off":
+			session.abortOnError = false
+		case "on":
+			session.abortOnError = true
+		default:
+			return fmt.Errorf("invalid `set -e` value (expected on or off)")
+		}
+		return nil
+
+	case strings.HasPrefix(line, "set "):
+		assignment := strings.TrimSpace(strings.TrimPrefix(line, "set "))
+		name, value, ok := strings.Cut(assignment, "=")
+		if !ok {
+			return fmt.Errorf("invalid `set` (expected VAR=value)")
+		}
+		return os.Setenv(strings.TrimSpace(name), expandVars(strings.TrimSpace(value)))
+
+	case strings.HasPrefix(line, "if "):
+		command, ok, err := evalIf(session, line)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+		return runScriptLine(session, command)
+
+	case strings.HasPrefix(line, "capture "):
+		assignment := strings.TrimSpace(strings.TrimPrefix(line, "capture "))
+		name, commandLine, ok := strings.Cut(assignment, "=")
+		if !ok {
+			return fmt.Errorf("invalid `capture` (expected VAR = command)")
+		}
+		return runCapture(session, strings.TrimSpace(name), strings.TrimSpace(commandLine))
+
+	default:
+		return runScriptCommand(session, line)
+	}
+}
+
+// ifRe matches `if last-exit-code <op> <n> then <command>`.
+var ifRe = regexp.MustCompile(`^if\s+last-exit-code\s*(!=|==|=)\s*(-?\d+)\s+then\s+(.+)$`)
+
+func evalIf(session *scriptSession, line string) (command string, matched bool, err error) {
+	m := ifRe.FindStringSubmatch(line)
+	if m == nil {
+		return "", false, fmt.Errorf("invalid `if` (expected: if last-exit-code != 0 then <command>)")
+	}
+	op, wantStr, command := m[1], m[2], m[3]
+	want, err := strconv.Atoi(wantStr)
+	if err != nil {
+		return "", false, fmt.Errorf("invalid exit code %q: %w", wantStr, err)
+	}
+
+	switch op {
+	case "!=":
+		return command, session.lastExitCode != want, nil
+	default: // "==" or "="
+		return command, session.lastExitCode == want, nil
+	}
+}
+
+// runScriptCommand runs a plain schemactl command line, expanding $VAR
+// references and recording its exit code for subsequent `if` lines.
+func runScriptCommand(session *scriptSession, line string) error {
+	args := parseArgs(expandVars(line))
+	if len(args) == 0 {
+		return nil
+	}
+	out, err := captureShellCommand(args)
+	fmt.Print(out)
+	if err != nil {
+		session.lastExitCode = 1
+		return err
+	}
+	session.lastExitCode = 0
+	return nil
+}
+
+// runCapture runs a command, storing its captured schema ID (or, failing
+// that, its trimmed output) into a script variable for later commands.
+func runCapture(session *scriptSession, varName, commandLine string) error {
+	args := parseArgs(expandVars(commandLine))
+	if len(args) == 0 {
+		return fmt.Errorf("empty `capture` command")
+	}
+	out, err := captureShellCommand(args)
+	if err != nil {
+		session.lastExitCode = 1
+		fmt.Print(out)
+		return err
+	}
+	session.lastExitCode = 0
+
+	value := strings.TrimSpace(out)
+	if m := scriptCaptureRe.FindString(out); m != "" {
+		value = m
+	}
+	return os.Setenv(varName, value)
+}
+
+// expandVars expands $VAR/${VAR} references using the script's environment
+// (set via `set VAR=value`), matching the $VAR expansion parseArgs already
+// does for interactive shell lines.
+func expandVars(s string) string {
+	return os.Expand(s, os.Getenv)
+}
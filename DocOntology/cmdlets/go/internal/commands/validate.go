@@ -0,0 +1,117 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/client"
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/output"
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/validate"
+	"github.com/spf13/cobra"
+)
+
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate schema content locally",
+	Long:  "Meta-validate a schema's content against JSON Schema draft-07/2020-12, plus this registry's custom format checkers (regex, date-iso, country-code, doctype-code). The schema is not sent to the API for validation; the country-code and doctype-code checkers use reference data fetched from the API first.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, _ := cmd.Flags().GetString("file")
+		stdin, _ := cmd.Flags().GetBool("stdin")
+		publicID, _ := cmd.Flags().GetString("publicId")
+		strict, _ := cmd.Flags().GetBool("strict")
+
+		set := 0
+		for _, v := range []bool{file != "", stdin, publicID != ""} {
+			if v {
+				set++
+			}
+		}
+		if set != 1 {
+			return fmt.Errorf("exactly one of --file, --stdin, or --publicId must be provided")
+		}
+
+		content, err := loadContent(cmd.Context(), file, stdin, publicID)
+		if err != nil {
+			return err
+		}
+
+		refData, err := GetClient().GetReferenceData(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("failed to load reference data for format checkers: %w", err)
+		}
+
+		errs, err := validate.Content(content, validate.Options{
+			Strict:       strict,
+			CountryCodes: countryCodes(refData.Countries),
+			DocTypeCodes: docTypeCodes(refData.DocTypes),
+		})
+		if err != nil {
+			return err
+		}
+
+		if output.JSONOutput {
+			return output.PrintJSON(errs)
+		}
+
+		if len(errs) == 0 {
+			output.PrintSuccess("Valid")
+			return nil
+		}
+
+		for _, e := range errs {
+			fmt.Println(e.String())
+		}
+		return fmt.Errorf("%d validation error(s)", len(errs))
+	},
+}
+
+func init() {
+	validateCmd.Flags().String("file", "", "Path to a JSON file containing schema content")
+	validateCmd.Flags().Bool("stdin", false, "Read schema content from stdin")
+	validateCmd.Flags().String("publicId", "", "Fetch a remote schema by publicId and validate its content")
+	validateCmd.Flags().Bool("strict", false, "Fail on unknown (non-draft) keywords")
+	rootCmd.AddCommand(validateCmd)
+}
+
+// loadContent resolves schema content from one of the three mutually
+// exclusive input sources accepted by validateCmd.
+func loadContent(ctx context.Context, file string, stdin bool, publicID string) (map[string]interface{}, error) {
+	switch {
+	case file != "":
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		return parseContent(string(data))
+	case stdin:
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read stdin: %w", err)
+		}
+		return parseContent(string(data))
+	default:
+		schema, err := GetClient().GetSchema(ctx, publicID)
+		if err != nil {
+			return nil, err
+		}
+		return schema.Content, nil
+	}
+}
+
+func docTypeCodes(docTypes []client.DocType) []string {
+	codes := make([]string, len(docTypes))
+	for i, dt := range docTypes {
+		codes[i] = dt.Code
+	}
+	return codes
+}
+
+func countryCodes(countries []client.Country) []string {
+	codes := make([]string, len(countries))
+	for i, c := range countries {
+		codes[i] = c.Code
+	}
+	return codes
+}
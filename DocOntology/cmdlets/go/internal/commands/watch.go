@@ -0,0 +1,21 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// addWatchFlags registers the `--watch`/`--watch-interval` pair shared by
+// every command that supports re-rendering on each poll instead of exiting
+// after one request. Every poll re-renders, whether or not anything changed.
+func addWatchFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("watch", false, "Keep polling and re-render after every poll, changed or not, until interrupted")
+	cmd.Flags().Duration("watch-interval", 3*time.Second, "Polling interval when --watch is set")
+}
+
+// watchInterval reads back the interval addWatchFlags registered.
+func watchInterval(cmd *cobra.Command) time.Duration {
+	interval, _ := cmd.Flags().GetDuration("watch-interval")
+	return interval
+}
@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
+
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/keyring"
 )
 
 // Config holds the application configuration
@@ -11,26 +14,68 @@ type Config struct {
 	APIBaseURL string
 	APIKey     string
 	Timeout    int
+
+	// MaxRetries is how many times the client retries a request that fails
+	// with a transient status (429, 502, 503, 504) or network error.
+	MaxRetries int
+
+	// RateLimit caps requests per second across every call on the client's
+	// HTTP transport (0 disables rate limiting).
+	RateLimit float64
+
+	// ActiveContext is the name of the context (if any) this config was
+	// resolved from, so the shell prompt and `config current-context` can
+	// display it.
+	ActiveContext string
+
+	// APISocketPath, if set, dials a Unix domain socket instead of TCP for
+	// every request, for deployments where the Schema Registry runs
+	// alongside schemactl on the same host or in a sidecar. APIBaseURL's
+	// host is still used to build request URLs, but never actually dialed.
+	APISocketPath string
+
+	// ClientCertFile/ClientKeyFile/CACertFile configure mutual TLS: a
+	// client certificate/key pair to present, and a CA pool to verify the
+	// server against instead of the system roots. InsecureSkipVerify
+	// disables server certificate verification entirely (for local/dev
+	// use only).
+	ClientCertFile     string
+	ClientKeyFile      string
+	CACertFile         string
+	InsecureSkipVerify bool
 }
 
 // DefaultTimeout is the default request timeout in seconds
 const DefaultTimeout = 30
 
+// DefaultMaxRetries is the default number of retry attempts for transient
+// request failures.
+const DefaultMaxRetries = 3
+
 // DefaultAPIURL is the default Schema Registry API URL
 const DefaultAPIURL = "https://api.docdigitizer.com/registry"
 
-// Load loads configuration from environment variables
-func Load() (*Config, error) {
-	apiURL := os.Getenv("SCHEMACTL_API_URL")
-	if apiURL == "" {
-		apiURL = DefaultAPIURL
-	}
+// keyringService is the service name schemactl registers secrets under when
+// resolving `keyring:<account>` API key references.
+const keyringService = "schemactl"
 
+// Load resolves configuration from, in increasing priority: the
+// SCHEMACTL_API_URL/SCHEMACTL_API_KEY/SCHEMACTL_TIMEOUT environment
+// variables, then the named context (contextName, falling back to
+// SCHEMACTL_CONTEXT and finally the file's currentContext) stored in
+// ~/.schemactl/config.yaml. Command-line flags are applied on top by the
+// caller via WithAPIURL/SetAPIKey.
+func Load(contextName string) (*Config, error) {
 	cfg := &Config{
-		APIBaseURL: apiURL,
-		APIKey:     os.Getenv("SCHEMACTL_API_KEY"),
+		APIBaseURL: DefaultAPIURL,
 		Timeout:    DefaultTimeout,
+		MaxRetries: DefaultMaxRetries,
+	}
+
+	if apiURL := os.Getenv("SCHEMACTL_API_URL"); apiURL != "" {
+		cfg.APIBaseURL = apiURL
 	}
+	cfg.APIKey = os.Getenv("SCHEMACTL_API_KEY")
 
 	if timeoutStr := os.Getenv("SCHEMACTL_TIMEOUT"); timeoutStr != "" {
 		timeout, err := strconv.Atoi(timeoutStr)
@@ -40,6 +85,57 @@ func Load() (*Config, error) {
 		cfg.Timeout = timeout
 	}
 
+	if retriesStr := os.Getenv("SCHEMACTL_MAX_RETRIES"); retriesStr != "" {
+		retries, err := strconv.Atoi(retriesStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SCHEMACTL_MAX_RETRIES: %w", err)
+		}
+		cfg.MaxRetries = retries
+	}
+
+	if rateStr := os.Getenv("SCHEMACTL_RATE_LIMIT"); rateStr != "" {
+		rateLimit, err := strconv.ParseFloat(rateStr, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SCHEMACTL_RATE_LIMIT: %w", err)
+		}
+		cfg.RateLimit = rateLimit
+	}
+
+	cfg.APISocketPath = os.Getenv("SCHEMACTL_API_SOCKET")
+	cfg.ClientCertFile = os.Getenv("SCHEMACTL_CLIENT_CERT")
+	cfg.ClientKeyFile = os.Getenv("SCHEMACTL_CLIENT_KEY")
+	cfg.CACertFile = os.Getenv("SCHEMACTL_CA_CERT")
+
+	file, err := LoadContextFile()
+	if err != nil {
+		return nil, err
+	}
+
+	if contextName == "" {
+		contextName = os.Getenv("SCHEMACTL_CONTEXT")
+	}
+	if contextName == "" {
+		contextName = file.CurrentContext
+	}
+
+	if contextName != "" {
+		ctx, ok := file.Find(contextName)
+		if !ok {
+			return nil, fmt.Errorf("context %q not found; run `schemactl config get-contexts` to list available contexts", contextName)
+		}
+		if ctx.APIURL != "" {
+			cfg.APIBaseURL = ctx.APIURL
+		}
+		if ctx.APIKey != "" {
+			key, err := ResolveAPIKey(ctx.APIKey)
+			if err != nil {
+				return nil, fmt.Errorf("context %q: %w", contextName, err)
+			}
+			cfg.APIKey = key
+		}
+		cfg.ActiveContext = contextName
+	}
+
 	return cfg, nil
 }
 
@@ -71,3 +167,70 @@ func (c *Config) WithAPIKey(key string) *Config {
 	}
 	return c
 }
+
+// WithAPISocketPath sets the Unix domain socket path on c, if path is
+// non-empty, and returns c.
+func (c *Config) WithAPISocketPath(path string) *Config {
+	if path != "" {
+		c.APISocketPath = path
+	}
+	return c
+}
+
+// WithClientTLS sets the mutual-TLS client certificate/key and CA pool on
+// c, for whichever of certFile/keyFile/caFile are non-empty, and returns c.
+func (c *Config) WithClientTLS(certFile, keyFile, caFile string) *Config {
+	if certFile != "" {
+		c.ClientCertFile = certFile
+	}
+	if keyFile != "" {
+		c.ClientKeyFile = keyFile
+	}
+	if caFile != "" {
+		c.CACertFile = caFile
+	}
+	return c
+}
+
+// SetAPIKey resolves key (a literal key, an `@/path/to/file` file reference,
+// or a `keyring:<account>` OS-keyring reference) and applies it to the
+// config. An empty key is a no-op, leaving any previously loaded key in
+// place. The `@`/`keyring:` forms exist so a real key never has to appear in
+// shell history or a process listing.
+func (c *Config) SetAPIKey(key string) error {
+	if key == "" {
+		return nil
+	}
+	resolved, err := ResolveAPIKey(key)
+	if err != nil {
+		return err
+	}
+	c.APIKey = resolved
+	return nil
+}
+
+// ResolveAPIKey interprets an API key reference:
+//   - "@/path/to/file" reads the key from a file (trimming trailing newline)
+//   - "keyring:<account>" looks the key up in the OS keyring under the
+//     "schemactl" service
+//   - anything else is returned as-is, as a literal key
+func ResolveAPIKey(ref string) (string, error) {
+	switch {
+	case strings.HasPrefix(ref, "@"):
+		path := strings.TrimPrefix(ref, "@")
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read API key from %s: %w", path, err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	case strings.HasPrefix(ref, "keyring:"):
+		account := strings.TrimPrefix(ref, "keyring:")
+		key, err := keyring.Get(keyringService, account)
+		if err != nil {
+			return "", err
+		}
+		return key, nil
+	default:
+		return ref, nil
+	}
+}
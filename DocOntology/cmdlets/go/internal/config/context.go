@@ -0,0 +1,120 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Context is one named {api-url, api-key} pair, analogous to a kubeconfig
+// context, so schemactl can switch between environments (e.g. dev/staging/
+// prod) without retyping --api-url/--api-key.
+type Context struct {
+	Name   string `yaml:"name"`
+	APIURL string `yaml:"apiUrl"`
+	APIKey string `yaml:"apiKey,omitempty"`
+}
+
+// ContextFile is the on-disk shape of ~/.schemactl/config.yaml.
+type ContextFile struct {
+	CurrentContext string    `yaml:"currentContext,omitempty"`
+	Contexts       []Context `yaml:"contexts"`
+}
+
+// ContextFilePath returns the path to the context config file, honoring
+// $SCHEMACTL_CONFIG if set.
+func ContextFilePath() (string, error) {
+	if p := os.Getenv("SCHEMACTL_CONFIG"); p != "" {
+		return p, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve home directory: %w", err)
+	}
+	return filepath.Join(home, ".schemactl", "config.yaml"), nil
+}
+
+// LoadContextFile reads the context file, returning a zero-value ContextFile
+// (not an error) if it doesn't exist yet.
+func LoadContextFile() (ContextFile, error) {
+	var file ContextFile
+
+	path, err := ContextFilePath()
+	if err != nil {
+		return file, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return file, nil
+		}
+		return file, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return file, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return file, nil
+}
+
+// SaveContextFile writes the context file, creating its parent directory
+// (with owner-only permissions, since it may hold an API key) if needed.
+func SaveContextFile(file ContextFile) error {
+	path, err := ContextFilePath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+
+	data, err := yaml.Marshal(file)
+	if err != nil {
+		return fmt.Errorf("failed to marshal context file: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// Find returns the context with the given name.
+func (f ContextFile) Find(name string) (Context, bool) {
+	for _, c := range f.Contexts {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return Context{}, false
+}
+
+// SetContext creates or replaces a context by name.
+func (f *ContextFile) SetContext(c Context) {
+	for i, existing := range f.Contexts {
+		if existing.Name == c.Name {
+			f.Contexts[i] = c
+			return
+		}
+	}
+	f.Contexts = append(f.Contexts, c)
+}
+
+// RemoveContext deletes a context by name, clearing CurrentContext if it
+// pointed at the one being removed. Reports whether a context was removed.
+func (f *ContextFile) RemoveContext(name string) bool {
+	for i, existing := range f.Contexts {
+		if existing.Name == name {
+			f.Contexts = append(f.Contexts[:i], f.Contexts[i+1:]...)
+			if f.CurrentContext == name {
+				f.CurrentContext = ""
+			}
+			return true
+		}
+	}
+	return false
+}
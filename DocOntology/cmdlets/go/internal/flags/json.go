@@ -0,0 +1,84 @@
+// Package flags provides small cobra flag helpers shared across this CLI's
+// resource commands — currently a generic --json input path (inline JSON,
+// @file, or "-" for stdin) that every create/update command can wire in
+// with one line, plus the merge helpers needed to layer individual flags
+// on top of it.
+package flags
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ReadJSON resolves a --json flag's value into raw bytes: an inline JSON
+// string, @file.json, or "-" for stdin.
+func ReadJSON(value string) ([]byte, error) {
+	switch {
+	case value == "-":
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read stdin: %w", err)
+		}
+		return data, nil
+	case strings.HasPrefix(value, "@"):
+		path := strings.TrimPrefix(value, "@")
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		return data, nil
+	default:
+		return []byte(value), nil
+	}
+}
+
+// UnmarshalJSONFlag resolves a --json flag's value (inline, @file, or "-"
+// for stdin) and unmarshals it into v.
+func UnmarshalJSONFlag(value string, v interface{}) error {
+	data, err := ReadJSON(value)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("invalid --json payload: %w", err)
+	}
+	return nil
+}
+
+// MergeString layers an individual flag/positional-arg value onto a string
+// field a --json payload may already have set. An empty value is a no-op
+// (nothing to merge). A non-empty value that disagrees with what --json
+// already set is rejected rather than silently overriding it, since that
+// almost always signals a mistake in the command line, not an intentional
+// override.
+func MergeString(field *string, value, fieldName string) error {
+	if value == "" {
+		return nil
+	}
+	if *field != "" && *field != value {
+		return fmt.Errorf("--json already sets %s to %q, which conflicts with %q", fieldName, *field, value)
+	}
+	*field = value
+	return nil
+}
+
+// MergeStringPtr is MergeString for an optional *string field.
+func MergeStringPtr(field **string, value, fieldName string) error {
+	if *field != nil && **field != value {
+		return fmt.Errorf("--json already sets %s to %q, which conflicts with %q", fieldName, **field, value)
+	}
+	*field = &value
+	return nil
+}
+
+// MergeBoolPtr is MergeString for an optional *bool field.
+func MergeBoolPtr(field **bool, value bool, fieldName string) error {
+	if *field != nil && **field != value {
+		return fmt.Errorf("--json already sets %s to %t, which conflicts with %t", fieldName, **field, value)
+	}
+	*field = &value
+	return nil
+}
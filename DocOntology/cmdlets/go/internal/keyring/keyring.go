@@ -0,0 +1,45 @@
+// Package keyring resolves secrets from the local OS credential store.
+// It shells out to the platform's keyring CLI (security on macOS,
+// secret-tool on Linux) rather than linking a keyring library, so this
+// package needs nothing beyond the standard library.
+package keyring
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Get looks up a secret by service and account name in the OS keyring.
+func Get(service, account string) (string, error) {
+	switch runtime.GOOS {
+	case "darwin":
+		return runSecurity(service, account)
+	case "linux":
+		return runSecretTool(service, account)
+	default:
+		return "", fmt.Errorf("OS keyring lookup is not supported on %s", runtime.GOOS)
+	}
+}
+
+func runSecurity(service, account string) (string, error) {
+	out, err := exec.Command("security", "find-generic-password", "-s", service, "-a", account, "-w").Output()
+	if err != nil {
+		return "", fmt.Errorf("keyring lookup failed (service=%s account=%s): %w", service, account, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+func runSecretTool(service, account string) (string, error) {
+	cmd := exec.Command("secret-tool", "lookup", "service", service, "account", account)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("keyring lookup failed (service=%s account=%s): %w: %s", service, account, err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
@@ -0,0 +1,103 @@
+// Package manifest parses the desired-state YAML/JSON documents consumed by
+// `schemactl apply`: multi-document streams describing schemas, doc-types,
+// and countries as a single source of truth.
+package manifest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Kind identifies which registry resource a manifest document describes.
+type Kind string
+
+const (
+	KindSchema  Kind = "Schema"
+	KindDocType Kind = "DocType"
+	KindCountry Kind = "Country"
+)
+
+// Metadata carries the identity fields used to match a manifest document
+// against an existing registry resource.
+type Metadata struct {
+	Code       string `yaml:"code" json:"code"`
+	Name       string `yaml:"name" json:"name"`
+	DocType    string `yaml:"docType" json:"docType"`
+	Country    string `yaml:"country" json:"country"`
+	CustomerID string `yaml:"customerId" json:"customerId"`
+}
+
+// Document is one `---`-separated entry in a manifest stream.
+type Document struct {
+	Kind     Kind                   `yaml:"kind" json:"kind"`
+	Metadata Metadata               `yaml:"metadata" json:"metadata"`
+	Spec     map[string]interface{} `yaml:"spec" json:"spec"`
+
+	// Source is the file the document was read from, used in error messages.
+	Source string `yaml:"-" json:"-"`
+}
+
+// ParsePath reads manifests from a single file or, if path is a directory,
+// every *.yaml/*.yml/*.json file inside it.
+func ParsePath(path string) ([]Document, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+
+	if !info.IsDir() {
+		return parseFile(path)
+	}
+
+	var docs []Document
+	err = filepath.Walk(path, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if fi.IsDir() {
+			return nil
+		}
+		ext := strings.ToLower(filepath.Ext(p))
+		if ext != ".yaml" && ext != ".yml" && ext != ".json" {
+			return nil
+		}
+		fileDocs, err := parseFile(p)
+		if err != nil {
+			return err
+		}
+		docs = append(docs, fileDocs...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+// parseFile splits a file into `---`-separated YAML documents (a plain JSON
+// file is valid YAML, so this path handles both).
+func parseFile(path string) ([]Document, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var docs []Document
+	decoder := yaml.NewDecoder(strings.NewReader(string(data)))
+	for {
+		var doc Document
+		if err := decoder.Decode(&doc); err != nil {
+			break
+		}
+		if doc.Kind == "" {
+			continue
+		}
+		doc.Source = path
+		docs = append(docs, doc)
+	}
+	return docs, nil
+}
@@ -0,0 +1,270 @@
+package openapi
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/miguel-bandeira-infosistema/schemactl/internal/client"
+)
+
+// enumValues maps the named string-enum types in internal/client to their
+// declared values, keyed by reflect.Type so Build can recognize them while
+// walking struct fields.
+var enumValues = map[reflect.Type][]string{
+	reflect.TypeOf(client.Status("")):     {string(client.StatusDraft), string(client.StatusActive), string(client.StatusDeprecated)},
+	reflect.TypeOf(client.Visibility("")): {string(client.VisibilityPublic), string(client.VisibilityCommunity), string(client.VisibilityPrivate)},
+	reflect.TypeOf(client.SchemaType("")): {string(client.SchemaTypeStandard), string(client.SchemaTypeRegex)},
+}
+
+// builder accumulates components while walking Go types, so a type referenced
+// from multiple places (e.g. DocType from both Schema and SchemaWithRelations)
+// is only projected once.
+type builder struct {
+	components map[string]*SchemaObject
+}
+
+// Build walks the canonical request/response types in internal/client and
+// produces an OpenAPI 3.0 document describing the routes the CLI calls. The
+// lifecycle routes of lifecycle.go (POST /admin/schemas/versions/{versionId}/actions
+// and GET /admin/schemas/{id}/history) are not included.
+func Build(apiURL string) *Document {
+	b := &builder{components: map[string]*SchemaObject{}}
+
+	doc := &Document{
+		OpenAPI: "3.0.3",
+		Info: Info{
+			Title:   "Schema Registry API",
+			Version: "generated",
+		},
+		Servers: []Server{{URL: apiURL}},
+		Paths:   map[string]*PathItem{},
+	}
+
+	// ErrorResponse is referenced by every operation's error responses.
+	b.schemaFor(reflect.TypeOf(client.ErrorResponse{}))
+
+	doc.Paths["/health"] = &PathItem{
+		Get: b.operation("getHealth", "Check API health", nil, nil, reflect.TypeOf(client.HealthResponse{})),
+	}
+
+	doc.Paths["/reference-data"] = &PathItem{
+		Get: b.operation("getReferenceData", "Get all active doc types and countries", nil, nil, reflect.TypeOf(client.ReferenceDataResponse{})),
+	}
+
+	doc.Paths["/doc-types"] = &PathItem{
+		Get: b.operation("listDocTypes", "List active doc types", nil, nil, reflect.SliceOf(reflect.TypeOf(client.DocType{}))),
+	}
+	doc.Paths["/admin/doc-types"] = &PathItem{
+		Get:  b.operation("listAllDocTypes", "List all doc types, including inactive", nil, nil, reflect.SliceOf(reflect.TypeOf(client.DocType{}))),
+		Post: b.operation("createDocType", "Create a doc type", reflect.TypeOf(client.CreateDocTypeRequest{}), nil, reflect.TypeOf(client.DocType{})),
+	}
+	doc.Paths["/admin/doc-types/{code}"] = &PathItem{
+		Get:    b.operation("getDocType", "Get a doc type by code", nil, []Parameter{pathParam("code")}, reflect.TypeOf(client.DocType{})),
+		Patch:  b.operation("updateDocType", "Update a doc type", reflect.TypeOf(client.UpdateDocTypeRequest{}), []Parameter{pathParam("code")}, reflect.TypeOf(client.DocType{})),
+		Delete: b.operation("deleteDocType", "Soft delete a doc type", nil, []Parameter{pathParam("code")}, nil),
+	}
+
+	doc.Paths["/countries"] = &PathItem{
+		Get: b.operation("listCountries", "List active countries", nil, nil, reflect.SliceOf(reflect.TypeOf(client.Country{}))),
+	}
+	doc.Paths["/admin/countries"] = &PathItem{
+		Get:  b.operation("listAllCountries", "List all countries, including inactive", nil, nil, reflect.SliceOf(reflect.TypeOf(client.Country{}))),
+		Post: b.operation("createCountry", "Create a country", reflect.TypeOf(client.CreateCountryRequest{}), nil, reflect.TypeOf(client.Country{})),
+	}
+	doc.Paths["/admin/countries/{code}"] = &PathItem{
+		Get:    b.operation("getCountry", "Get a country by code", nil, []Parameter{pathParam("code")}, reflect.TypeOf(client.Country{})),
+		Patch:  b.operation("updateCountry", "Update a country", reflect.TypeOf(client.UpdateCountryRequest{}), []Parameter{pathParam("code")}, reflect.TypeOf(client.Country{})),
+		Delete: b.operation("deleteCountry", "Soft delete a country", nil, []Parameter{pathParam("code")}, nil),
+	}
+
+	doc.Paths["/admin/schemas"] = &PathItem{
+		Get:  b.operation("listSchemas", "List schemas with optional filtering", nil, listSchemasParams(), reflect.TypeOf(client.PaginatedSchemaList{})),
+		Post: b.operation("createSchema", "Create a schema", reflect.TypeOf(client.CreateSchemaRequest{}), nil, reflect.TypeOf(client.SchemaWithRelations{})),
+	}
+	doc.Paths["/admin/schemas/{id}"] = &PathItem{
+		Get:    b.operation("getSchema", "Get a schema by publicId or publicVersionId", nil, []Parameter{pathParam("id")}, reflect.TypeOf(client.SchemaWithRelations{})),
+		Patch:  b.operation("updateSchema", "Update a schema", reflect.TypeOf(client.UpdateSchemaRequest{}), []Parameter{pathParam("id")}, reflect.TypeOf(client.SchemaWithRelations{})),
+		Delete: b.operation("deleteSchema", "Delete a draft schema", nil, []Parameter{pathParam("id")}, nil),
+	}
+	doc.Paths["/admin/schemas/{id}/versions"] = &PathItem{
+		Get: b.operation("getSchemaVersions", "List all versions of a schema", nil, []Parameter{pathParam("id")}, reflect.SliceOf(reflect.TypeOf(client.SchemaWithRelations{}))),
+	}
+	doc.Paths["/admin/schemas/versions/{versionId}"] = &PathItem{
+		Get: b.operation("getSchemaVersion", "Get a specific schema version", nil, []Parameter{pathParam("versionId")}, reflect.TypeOf(client.SchemaWithRelations{})),
+	}
+	doc.Paths["/admin/schemas/{id}/activate"] = &PathItem{
+		Post: b.operation("activateSchema", "Activate a draft schema", nil, []Parameter{pathParam("id")}, reflect.TypeOf(client.SchemaWithRelations{})),
+	}
+	doc.Paths["/admin/schemas/{id}/deprecate"] = &PathItem{
+		Post: b.operation("deprecateSchema", "Deprecate an active schema", nil, []Parameter{pathParam("id")}, reflect.TypeOf(client.SchemaWithRelations{})),
+	}
+	doc.Paths["/schemas/find-best"] = &PathItem{
+		Post: b.operation("findBestSchema", "Find the best matching schema", reflect.TypeOf(client.FindBestRequest{}), nil, reflect.TypeOf(client.FindBestResponse{})),
+	}
+	doc.Paths["/schemas/extract"] = &PathItem{
+		Post: b.operation("matchSchema", "Upload a file, classify it, and find a matching schema", nil, nil, reflect.TypeOf(client.ExtractResponse{})),
+	}
+	doc.Paths["/schemas/generate"] = &PathItem{
+		Post: b.operation("generateSchema", "Generate a schema from a document via LLM", nil, nil, reflect.TypeOf(client.GenerateResponse{})),
+	}
+
+	doc.Components.Schemas = b.components
+	return doc
+}
+
+func pathParam(name string) Parameter {
+	return Parameter{Name: name, In: "path", Required: true, Schema: &SchemaObject{Type: "string"}}
+}
+
+func listSchemasParams() []Parameter {
+	names := []string{"status", "docTypeCode", "countryCode", "visibility", "customerId"}
+	params := make([]Parameter, 0, len(names)+2)
+	for _, n := range names {
+		params = append(params, Parameter{Name: n, In: "query", Schema: &SchemaObject{Type: "string"}})
+	}
+	params = append(params,
+		Parameter{Name: "limit", In: "query", Schema: &SchemaObject{Type: "integer"}},
+		Parameter{Name: "offset", In: "query", Schema: &SchemaObject{Type: "integer"}},
+	)
+	return params
+}
+
+// operation builds an Operation for a single route, registering the request
+// and response body schemas (if any) in the shared component map.
+func (b *builder) operation(opID, summary string, reqType reflect.Type, params []Parameter, respType reflect.Type) *Operation {
+	op := &Operation{
+		OperationID: opID,
+		Summary:     summary,
+		Parameters:  params,
+		Responses: map[string]Response{
+			"400": {Description: "Bad request", Content: map[string]MediaType{"application/json": {Schema: ref("ErrorResponse")}}},
+			"404": {Description: "Not found", Content: map[string]MediaType{"application/json": {Schema: ref("ErrorResponse")}}},
+		},
+	}
+
+	if reqType != nil {
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]MediaType{"application/json": {Schema: b.schemaFor(reqType)}},
+		}
+	}
+
+	okResp := Response{Description: "OK"}
+	if respType != nil {
+		okResp.Content = map[string]MediaType{"application/json": {Schema: b.schemaFor(respType)}}
+	}
+	op.Responses["200"] = okResp
+
+	return op
+}
+
+// schemaFor projects a Go type into an OpenAPI SchemaObject, registering
+// named struct/slice types as components and returning a $ref to them.
+func (b *builder) schemaFor(t reflect.Type) *SchemaObject {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == reflect.TypeOf(time.Time{}) {
+		return &SchemaObject{Type: "string", Format: "date-time"}
+	}
+
+	if values, ok := enumValues[t]; ok {
+		return &SchemaObject{Type: "string", Enum: values}
+	}
+
+	switch t.Kind() {
+	case reflect.Slice:
+		return &SchemaObject{Type: "array", Items: b.schemaFor(t.Elem())}
+	case reflect.Map:
+		return &SchemaObject{Type: "object", AdditionalProperties: &SchemaObject{}}
+	case reflect.String:
+		return &SchemaObject{Type: "string"}
+	case reflect.Bool:
+		return &SchemaObject{Type: "boolean"}
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return &SchemaObject{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &SchemaObject{Type: "number"}
+	case reflect.Struct:
+		name := t.Name()
+		if _, ok := b.components[name]; ok {
+			return ref(name)
+		}
+		// Reserve the name before recursing, so self-referential or
+		// mutually-referential structs don't loop forever.
+		b.components[name] = &SchemaObject{}
+		b.components[name] = b.structSchema(t)
+		return ref(name)
+	default:
+		return &SchemaObject{}
+	}
+}
+
+// structSchema projects every field of a struct type, honoring `json:` tags,
+// `omitempty`, and embedded-field promotion (used by SchemaWithRelations).
+func (b *builder) structSchema(t reflect.Type) *SchemaObject {
+	s := &SchemaObject{Type: "object", Properties: map[string]*SchemaObject{}}
+
+	// allOf composition for types that embed another named struct, e.g.
+	// SchemaWithRelations embeds Schema.
+	var allOf []*SchemaObject
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous {
+			allOf = append(allOf, b.schemaFor(f.Type))
+			continue
+		}
+
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts := parseJSONTag(tag, f.Name)
+
+		fieldSchema := b.schemaFor(f.Type)
+		if f.Type.Kind() == reflect.Ptr {
+			fieldSchema = cloneNullable(fieldSchema)
+		}
+		s.Properties[name] = fieldSchema
+
+		if !opts["omitempty"] && f.Type.Kind() != reflect.Ptr {
+			s.Required = append(s.Required, name)
+		}
+	}
+
+	if len(allOf) > 0 {
+		allOf = append(allOf, s)
+		return &SchemaObject{AllOf: allOf}
+	}
+	return s
+}
+
+func cloneNullable(s *SchemaObject) *SchemaObject {
+	if s.Ref != "" {
+		// $ref siblings are ignored by strict OpenAPI 3.0 tooling, so wrap
+		// pointer-to-struct fields in an allOf instead of setting Nullable
+		// directly on the ref.
+		return &SchemaObject{AllOf: []*SchemaObject{s}, Nullable: true}
+	}
+	clone := *s
+	clone.Nullable = true
+	return &clone
+}
+
+// parseJSONTag splits a struct field's `json:` tag into its field name and
+// option set, falling back to the Go field name when the tag is empty.
+func parseJSONTag(tag, fieldName string) (string, map[string]bool) {
+	parts := strings.Split(tag, ",")
+	name := fieldName
+	if parts[0] != "" {
+		name = parts[0]
+	}
+	opts := map[string]bool{}
+	for _, o := range parts[1:] {
+		opts[o] = true
+	}
+	return name, opts
+}
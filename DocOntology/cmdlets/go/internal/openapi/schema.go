@@ -0,0 +1,90 @@
+// Package openapi builds an OpenAPI 3.0 document describing the Schema
+// Registry API surface that this CLI talks to, derived from the request and
+// response structs declared in internal/client.
+package openapi
+
+// SchemaObject is a (deliberately partial) projection of an OpenAPI 3.0
+// Schema Object, covering the subset this builder ever emits.
+type SchemaObject struct {
+	Type                 string                   `json:"type,omitempty" yaml:"type,omitempty"`
+	Format               string                   `json:"format,omitempty" yaml:"format,omitempty"`
+	Nullable             bool                     `json:"nullable,omitempty" yaml:"nullable,omitempty"`
+	Enum                 []string                 `json:"enum,omitempty" yaml:"enum,omitempty"`
+	Properties           map[string]*SchemaObject `json:"properties,omitempty" yaml:"properties,omitempty"`
+	Required             []string                 `json:"required,omitempty" yaml:"required,omitempty"`
+	Items                *SchemaObject            `json:"items,omitempty" yaml:"items,omitempty"`
+	AdditionalProperties *SchemaObject            `json:"additionalProperties,omitempty" yaml:"additionalProperties,omitempty"`
+	Ref                  string                   `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	AllOf                []*SchemaObject          `json:"allOf,omitempty" yaml:"allOf,omitempty"`
+}
+
+// Parameter is a partial OpenAPI 3.0 Parameter Object.
+type Parameter struct {
+	Name     string        `json:"name" yaml:"name"`
+	In       string        `json:"in" yaml:"in"`
+	Required bool          `json:"required,omitempty" yaml:"required,omitempty"`
+	Schema   *SchemaObject `json:"schema" yaml:"schema"`
+}
+
+// MediaType is a partial OpenAPI 3.0 Media Type Object.
+type MediaType struct {
+	Schema *SchemaObject `json:"schema" yaml:"schema"`
+}
+
+// RequestBody is a partial OpenAPI 3.0 Request Body Object.
+type RequestBody struct {
+	Required bool                 `json:"required,omitempty" yaml:"required,omitempty"`
+	Content  map[string]MediaType `json:"content" yaml:"content"`
+}
+
+// Response is a partial OpenAPI 3.0 Response Object.
+type Response struct {
+	Description string               `json:"description" yaml:"description"`
+	Content     map[string]MediaType `json:"content,omitempty" yaml:"content,omitempty"`
+}
+
+// Operation is a partial OpenAPI 3.0 Operation Object.
+type Operation struct {
+	OperationID string              `json:"operationId" yaml:"operationId"`
+	Summary     string              `json:"summary,omitempty" yaml:"summary,omitempty"`
+	Parameters  []Parameter         `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	RequestBody *RequestBody        `json:"requestBody,omitempty" yaml:"requestBody,omitempty"`
+	Responses   map[string]Response `json:"responses" yaml:"responses"`
+}
+
+// PathItem groups the operations available on a single path.
+type PathItem struct {
+	Get    *Operation `json:"get,omitempty" yaml:"get,omitempty"`
+	Post   *Operation `json:"post,omitempty" yaml:"post,omitempty"`
+	Patch  *Operation `json:"patch,omitempty" yaml:"patch,omitempty"`
+	Delete *Operation `json:"delete,omitempty" yaml:"delete,omitempty"`
+}
+
+// Components holds the document's reusable schemas.
+type Components struct {
+	Schemas map[string]*SchemaObject `json:"schemas" yaml:"schemas"`
+}
+
+// Info is the OpenAPI Info Object.
+type Info struct {
+	Title   string `json:"title" yaml:"title"`
+	Version string `json:"version" yaml:"version"`
+}
+
+// Server is the OpenAPI Server Object.
+type Server struct {
+	URL string `json:"url" yaml:"url"`
+}
+
+// Document is the root OpenAPI 3.0 document this builder produces.
+type Document struct {
+	OpenAPI    string               `json:"openapi" yaml:"openapi"`
+	Info       Info                 `json:"info" yaml:"info"`
+	Servers    []Server             `json:"servers,omitempty" yaml:"servers,omitempty"`
+	Paths      map[string]*PathItem `json:"paths" yaml:"paths"`
+	Components Components           `json:"components" yaml:"components"`
+}
+
+func ref(name string) *SchemaObject {
+	return &SchemaObject{Ref: "#/components/schemas/" + name}
+}
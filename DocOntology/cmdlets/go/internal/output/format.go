@@ -0,0 +1,283 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format selects how RowWriter-based commands render their output, via
+// --output/-o (table, json, jsonl, yaml, csv, tsv, template). --json is kept
+// as a deprecated alias for --output json. Rather than a per-format
+// OutputFormat{ID, AttachFlags, Format(ctx, data)} plugin object, formats are
+// implemented as RowWriter, which list commands feed through PrintRows
+// after collecting their rows (one result page) in memory;
+// single-object commands (schemas get, doc-types get, ...) fall back to
+// PrintJSON/key-value printing directly, since there's no row shape to
+// write.
+type Format string
+
+const (
+	FormatTable    Format = "table"
+	FormatJSON     Format = "json"
+	FormatJSONL    Format = "jsonl"
+	FormatYAML     Format = "yaml"
+	FormatCSV      Format = "csv"
+	FormatTSV      Format = "tsv"
+	FormatTemplate Format = "template"
+)
+
+var (
+	// ActiveFormat is the format selected by --output. Defaults to table.
+	ActiveFormat = FormatTable
+
+	// TemplateText is the Go text/template body passed via --template,
+	// only consulted when ActiveFormat is FormatTemplate.
+	TemplateText string
+
+	// Fields, when non-empty, projects PrintRows output (every format) down to the
+	// named columns (--fields code,name,description). Column names are
+	// matched against headers case-insensitively, ignoring spaces/hyphens.
+	Fields []string
+)
+
+// ParseFormat parses the --output flag value.
+func ParseFormat(s string) (Format, error) {
+	switch Format(strings.ToLower(s)) {
+	case FormatTable, FormatJSON, FormatJSONL, FormatYAML, FormatCSV, FormatTSV, FormatTemplate:
+		return Format(strings.ToLower(s)), nil
+	default:
+		return "", fmt.Errorf("invalid --output value %q (expected table, json, jsonl, yaml, csv, tsv, or template)", s)
+	}
+}
+
+// RowWriter writes a table-shaped result (headers plus rows of string
+// cells) out in whatever Format the caller selected, one WriteRow call per
+// row. Commands that list many items (schemas, doc types, countries) hand
+// it rows they have already collected in memory, via PrintRows.
+type RowWriter interface {
+	WriteHeader(headers []string) error
+	WriteRow(row []string) error
+	Close() error
+}
+
+// NewRowWriter returns the RowWriter for the current ActiveFormat. JSON and
+// YAML buffer rows and emit a single array on Close, since both formats
+// need the closing bracket, and table buffers rows to size its columns;
+// CSV, TSV, JSONL, and template all write as they go.
+func NewRowWriter(w io.Writer) (RowWriter, error) {
+	switch ActiveFormat {
+	case FormatJSON:
+		return &bufferedRowWriter{w: w, yamlOut: false}, nil
+	case FormatYAML:
+		return &bufferedRowWriter{w: w, yamlOut: true}, nil
+	case FormatJSONL:
+		return &jsonlRowWriter{w: w}, nil
+	case FormatCSV:
+		return &delimitedRowWriter{cw: csv.NewWriter(w)}, nil
+	case FormatTSV:
+		cw := csv.NewWriter(w)
+		cw.Comma = '\t'
+		return &delimitedRowWriter{cw: cw}, nil
+	case FormatTemplate:
+		tmpl, err := template.New("output").Funcs(templateFuncs).Parse(TemplateText)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --template: %w", err)
+		}
+		return &templateRowWriter{w: w, tmpl: tmpl}, nil
+	default:
+		return &tableRowWriter{w: w}, nil
+	}
+}
+
+// PrintRows projects headers/rows down to --fields (if set) and streams
+// them through the RowWriter for ActiveFormat.
+func PrintRows(headers []string, rows [][]string) error {
+	headers, rows = ProjectFields(headers, rows)
+	rw, err := NewRowWriter(os.Stdout)
+	if err != nil {
+		return err
+	}
+	if err := rw.WriteHeader(headers); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := rw.WriteRow(row); err != nil {
+			return err
+		}
+	}
+	return rw.Close()
+}
+
+// ProjectFields narrows headers/rows down to the columns named in Fields.
+// Unrecognized or empty Fields leave headers/rows untouched.
+func ProjectFields(headers []string, rows [][]string) ([]string, [][]string) {
+	if len(Fields) == 0 {
+		return headers, rows
+	}
+	wanted := map[string]bool{}
+	for _, f := range Fields {
+		wanted[normalizeFieldName(f)] = true
+	}
+
+	var idx []int
+	var newHeaders []string
+	for i, h := range headers {
+		if wanted[normalizeFieldName(h)] {
+			idx = append(idx, i)
+			newHeaders = append(newHeaders, h)
+		}
+	}
+	if len(idx) == 0 {
+		return headers, rows
+	}
+
+	newRows := make([][]string, len(rows))
+	for i, row := range rows {
+		newRow := make([]string, len(idx))
+		for j, k := range idx {
+			if k < len(row) {
+				newRow[j] = row[k]
+			}
+		}
+		newRows[i] = newRow
+	}
+	return newHeaders, newRows
+}
+
+func normalizeFieldName(s string) string {
+	return strings.NewReplacer(" ", "", "-", "", "_", "").Replace(strings.ToLower(s))
+}
+
+// tableRowWriter buffers rows and delegates to PrintTable on Close, since
+// column widths can only be known once every row has been seen.
+type tableRowWriter struct {
+	w       io.Writer
+	headers []string
+	rows    [][]string
+}
+
+func (t *tableRowWriter) WriteHeader(headers []string) error { t.headers = headers; return nil }
+func (t *tableRowWriter) WriteRow(row []string) error        { t.rows = append(t.rows, row); return nil }
+func (t *tableRowWriter) Close() error                       { PrintTable(t.headers, t.rows); return nil }
+
+// delimitedRowWriter streams CSV/TSV rows as they arrive.
+type delimitedRowWriter struct {
+	cw *csv.Writer
+}
+
+func (d *delimitedRowWriter) WriteHeader(headers []string) error { return d.cw.Write(headers) }
+func (d *delimitedRowWriter) WriteRow(row []string) error        { return d.cw.Write(row) }
+func (d *delimitedRowWriter) Close() error                       { d.cw.Flush(); return d.cw.Error() }
+
+// jsonlRowWriter emits one JSON object per row as soon as it arrives.
+type jsonlRowWriter struct {
+	w       io.Writer
+	headers []string
+}
+
+func (j *jsonlRowWriter) WriteHeader(headers []string) error {
+	j.headers = headers
+	return nil
+}
+
+func (j *jsonlRowWriter) WriteRow(row []string) error {
+	data, err := json.Marshal(rowToMap(j.headers, row))
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(j.w, string(data))
+	return err
+}
+
+func (j *jsonlRowWriter) Close() error { return nil }
+
+// bufferedRowWriter collects rows as maps and emits them as a single JSON
+// or YAML array on Close.
+type bufferedRowWriter struct {
+	w       io.Writer
+	yamlOut bool
+	headers []string
+	items   []map[string]string
+}
+
+func (b *bufferedRowWriter) WriteHeader(headers []string) error {
+	b.headers = headers
+	return nil
+}
+
+func (b *bufferedRowWriter) WriteRow(row []string) error {
+	b.items = append(b.items, rowToMap(b.headers, row))
+	return nil
+}
+
+func (b *bufferedRowWriter) Close() error {
+	if b.yamlOut {
+		data, err := yaml.Marshal(b.items)
+		if err != nil {
+			return err
+		}
+		_, err = b.w.Write(data)
+		return err
+	}
+	encoder := json.NewEncoder(b.w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(b.items)
+}
+
+// templateRowWriter executes a user-supplied Go text/template once per row.
+type templateRowWriter struct {
+	w       io.Writer
+	tmpl    *template.Template
+	headers []string
+}
+
+func (t *templateRowWriter) WriteHeader(headers []string) error {
+	t.headers = headers
+	return nil
+}
+
+func (t *templateRowWriter) WriteRow(row []string) error {
+	var buf bytes.Buffer
+	if err := t.tmpl.Execute(&buf, rowToMap(t.headers, row)); err != nil {
+		return fmt.Errorf("template execution failed: %w", err)
+	}
+	_, err := fmt.Fprintln(t.w, buf.String())
+	return err
+}
+
+func (t *templateRowWriter) Close() error { return nil }
+
+func rowToMap(headers, row []string) map[string]string {
+	m := make(map[string]string, len(headers))
+	for i, h := range headers {
+		if i < len(row) {
+			m[h] = row[i]
+		}
+	}
+	return m
+}
+
+// templateFuncs are the sprig-style helpers available to --template: a
+// small hand-rolled set built on stdlib strings, matching this repo's
+// general preference for stdlib over adding a template-helper dependency.
+var templateFuncs = template.FuncMap{
+	"upper":    strings.ToUpper,
+	"lower":    strings.ToLower,
+	"trim":     strings.TrimSpace,
+	"contains": strings.Contains,
+	"join":     strings.Join,
+	"default": func(def, s string) string {
+		if s == "" {
+			return def
+		}
+		return s
+	},
+}
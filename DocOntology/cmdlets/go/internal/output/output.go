@@ -1,15 +1,22 @@
 package output
 
 import (
+	"bufio"
 	"encoding/json"
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
 )
 
 // JSONOutput controls whether output should be in JSON format
 var JSONOutput bool
 
+// ColorJSON controls whether PrintJSON highlights its output with ANSI
+// color codes. Off by default (and whenever output might be piped or
+// redirected); the interactive shell turns it on for terminal-facing JSON.
+var ColorJSON bool
+
 // PrintTable prints data in table format
 func PrintTable(headers []string, rows [][]string) {
 	if len(headers) == 0 {
@@ -71,11 +78,47 @@ func PrintKeyValue(data map[string]string) {
 	}
 }
 
-// PrintJSON prints data as JSON
+// PrintJSON prints data as JSON, syntax-highlighted when ColorJSON is set.
 func PrintJSON(v interface{}) error {
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(v)
+	if !ColorJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
+	}
+
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Println(highlightJSON(string(data)))
+	return err
+}
+
+var (
+	jsonToken = regexp.MustCompile(`"(\\.|[^"\\])*"\s*:|"(\\.|[^"\\])*"|\b(true|false|null)\b|-?\d+(\.\d+)?([eE][+-]?\d+)?`)
+
+	jsonKeyColor     = "\033[36m"
+	jsonStringColor  = "\033[32m"
+	jsonKeywordColor = "\033[35m"
+	jsonNumberColor  = "\033[33m"
+	jsonColorReset   = "\033[0m"
+)
+
+// highlightJSON colorizes already-marshaled JSON text for terminal display:
+// keys cyan, string values green, true/false/null magenta, numbers yellow.
+func highlightJSON(s string) string {
+	return jsonToken.ReplaceAllStringFunc(s, func(m string) string {
+		switch {
+		case strings.HasSuffix(m, ":"):
+			return jsonKeyColor + m + jsonColorReset
+		case strings.HasPrefix(m, `"`):
+			return jsonStringColor + m + jsonColorReset
+		case m == "true" || m == "false" || m == "null":
+			return jsonKeywordColor + m + jsonColorReset
+		default:
+			return jsonNumberColor + m + jsonColorReset
+		}
+	})
 }
 
 // PrintSuccess prints a success message
@@ -88,6 +131,48 @@ func PrintError(err error) {
 	fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 }
 
+// Confirm prompts the user with prompt + " [y/N]: " and reads a line from
+// stdin, returning true only for an explicit y/yes (case-insensitive).
+func Confirm(prompt string) bool {
+	fmt.Printf("%s [y/N]: ", prompt)
+	line, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	line = strings.ToLower(strings.TrimSpace(line))
+	return line == "y" || line == "yes"
+}
+
+// NewProgressFunc returns an upload progress callback (matching
+// client.ProgressFunc's signature without importing internal/client) that
+// redraws a single line on stderr as bytesSent advances toward totalBytes,
+// labelled with label (typically the file being uploaded). It leaves a
+// trailing newline once the upload completes so later output doesn't
+// overwrite it.
+func NewProgressFunc(label string) func(bytesSent, totalBytes int64) {
+	return func(bytesSent, totalBytes int64) {
+		pct := 100.0
+		if totalBytes > 0 {
+			pct = float64(bytesSent) * 100 / float64(totalBytes)
+		}
+		fmt.Fprintf(os.Stderr, "\r%s: %s / %s (%.0f%%)", label, humanBytes(bytesSent), humanBytes(totalBytes), pct)
+		if bytesSent >= totalBytes {
+			fmt.Fprintln(os.Stderr)
+		}
+	}
+}
+
+// humanBytes formats n using binary (KiB/MiB/...) units.
+func humanBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
 // Print handles printing based on JSONOutput flag
 func Print(v interface{}, tableHeaders []string, tableRows [][]string) {
 	if JSONOutput {
@@ -0,0 +1,97 @@
+// Package prompt implements the interactive fallback used by commands whose
+// required positional arguments (a resource code, a set of fields to create
+// one) were left off: a searchable selector for picking an existing
+// resource by its "named ID map" (code — name), or a sequence of text
+// prompts for supplying one. It's only ever reached when prompting is
+// actually appropriate — see Enabled — so scripts and non-TTY invocations
+// keep failing fast on a missing argument instead of hanging.
+package prompt
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/manifoldco/promptui"
+)
+
+// NoPrompt disables Select/Sequence entirely, set from the global
+// --no-prompt flag.
+var NoPrompt bool
+
+// Enabled reports whether Select/Sequence should run at all. Prompting is
+// suppressed by --no-prompt, JSON/JSONL output (a script reading stdout
+// doesn't want a survey interleaved with it), or stdin/stdout not being a
+// terminal.
+func Enabled(jsonOutput bool) bool {
+	return !NoPrompt && !jsonOutput && isTerminal(os.Stdin) && isTerminal(os.Stdout)
+}
+
+// Item is one entry of a Select list: Code is what's returned on selection,
+// Label is what's displayed and searched (e.g. "INV — Invoice").
+type Item struct {
+	Code  string
+	Label string
+}
+
+// Select presents items in a searchable list (filtered as the user types)
+// and returns the Code of the one chosen.
+func Select(label string, items []Item) (string, error) {
+	if len(items) == 0 {
+		return "", fmt.Errorf("nothing to select: no %s available", label)
+	}
+	labels := make([]string, len(items))
+	for i, it := range items {
+		labels[i] = it.Label
+	}
+	sel := promptui.Select{
+		Label: label,
+		Items: labels,
+		Searcher: func(input string, index int) bool {
+			return strings.Contains(strings.ToLower(labels[index]), strings.ToLower(input))
+		},
+	}
+	idx, _, err := sel.Run()
+	if err != nil {
+		return "", fmt.Errorf("prompt cancelled: %w", err)
+	}
+	return items[idx].Code, nil
+}
+
+// Field is one sequential text prompt, for resource creation.
+type Field struct {
+	Name     string
+	Required bool
+}
+
+// Sequence prompts for each Field in order and returns the entered values
+// keyed by Field.Name. A non-Required field may be left blank.
+func Sequence(fields []Field) (map[string]string, error) {
+	values := make(map[string]string, len(fields))
+	for _, f := range fields {
+		field := f
+		p := promptui.Prompt{Label: field.Name}
+		if field.Required {
+			p.Validate = func(s string) error {
+				if strings.TrimSpace(s) == "" {
+					return fmt.Errorf("%s is required", field.Name)
+				}
+				return nil
+			}
+		}
+		value, err := p.Run()
+		if err != nil {
+			return nil, fmt.Errorf("prompt cancelled: %w", err)
+		}
+		values[field.Name] = value
+	}
+	return values, nil
+}
+
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,369 @@
+// Package query parses the --filter/--fields/--sort flags shared by "list"
+// commands across the module into a form that can either be URL-encoded as
+// query parameters for the server, or applied locally in Go against a
+// []map[string]interface{} view of the results (the --client-filter
+// fallback, for servers that don't understand the query parameters yet).
+package query
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Predicate is one "field op value" term of a --filter expression, e.g.
+// `name eq "Invoice"` or `isActive eq true`.
+type Predicate struct {
+	Field string
+	Op    string
+	Value string
+}
+
+// Filter is a --filter expression: a list of Predicates combined uniformly
+// by Combinator ("and" or "or"). Mixing and/or in a single expression isn't
+// supported — ParseFilter rejects it rather than guessing precedence.
+type Filter struct {
+	Predicates []Predicate
+	Combinator string
+}
+
+// supportedOps are the comparison operators a predicate may use. gt/lt/ge/le
+// compare numerically; the rest compare the string form of the value.
+var supportedOps = map[string]bool{
+	"eq": true, "ne": true, "sw": true, "ew": true,
+	"contains": true, "gt": true, "lt": true, "ge": true, "le": true,
+}
+
+// ParseFilter parses a --filter expression of predicates combined by a
+// single and/or combinator, e.g. `name eq "Invoice" and code sw "INV"`. An
+// empty expression returns a nil *Filter (matches everything).
+func ParseFilter(expr string) (*Filter, error) {
+	if strings.TrimSpace(expr) == "" {
+		return nil, nil
+	}
+
+	tokens, err := tokenizeFilter(expr)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens)%4 != 3 {
+		return nil, fmt.Errorf("malformed --filter expression %q (expected \"field op value\" terms joined by and/or)", expr)
+	}
+
+	pred, err := parsePredicate(tokens[:3])
+	if err != nil {
+		return nil, err
+	}
+	f := &Filter{Predicates: []Predicate{pred}}
+
+	for i := 3; i < len(tokens); i += 4 {
+		combinator := strings.ToLower(tokens[i])
+		if combinator != "and" && combinator != "or" {
+			return nil, fmt.Errorf("--filter: expected \"and\" or \"or\", got %q", tokens[i])
+		}
+		if f.Combinator != "" && f.Combinator != combinator {
+			return nil, fmt.Errorf("--filter: mixing \"and\" and \"or\" in one expression is not supported")
+		}
+		f.Combinator = combinator
+
+		pred, err := parsePredicate(tokens[i+1 : i+4])
+		if err != nil {
+			return nil, err
+		}
+		f.Predicates = append(f.Predicates, pred)
+	}
+	if f.Combinator == "" {
+		f.Combinator = "and"
+	}
+	return f, nil
+}
+
+// tokenizeFilter splits a --filter expression on whitespace, keeping
+// double-quoted values (which may contain spaces) as single tokens.
+func tokenizeFilter(expr string) ([]string, error) {
+	var tokens []string
+	var cur strings.Builder
+	inQuotes := false
+
+	flush := func() {
+		if cur.Len() > 0 {
+			tokens = append(tokens, cur.String())
+			cur.Reset()
+		}
+	}
+	for _, r := range expr {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			cur.WriteRune(r)
+		case unicode.IsSpace(r) && !inQuotes:
+			flush()
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	if inQuotes {
+		return nil, fmt.Errorf("--filter: unterminated quoted value in %q", expr)
+	}
+	flush()
+	return tokens, nil
+}
+
+func parsePredicate(tokens []string) (Predicate, error) {
+	field, op := tokens[0], strings.ToLower(tokens[1])
+	if !supportedOps[op] {
+		return Predicate{}, fmt.Errorf("--filter: unsupported operator %q", tokens[1])
+	}
+	return Predicate{Field: field, Op: op, Value: strings.Trim(tokens[2], `"`)}, nil
+}
+
+// String renders f back into --filter's own syntax, suitable for
+// URL-encoding as the "filter" query parameter.
+func (f *Filter) String() string {
+	if f == nil || len(f.Predicates) == 0 {
+		return ""
+	}
+	combinator := f.Combinator
+	if combinator == "" {
+		combinator = "and"
+	}
+	parts := make([]string, len(f.Predicates))
+	for i, p := range f.Predicates {
+		parts[i] = fmt.Sprintf("%s %s %s", p.Field, p.Op, quoteValue(p.Value))
+	}
+	return strings.Join(parts, " "+combinator+" ")
+}
+
+func quoteValue(v string) string {
+	if v == "true" || v == "false" {
+		return v
+	}
+	if _, err := strconv.ParseFloat(v, 64); err == nil {
+		return v
+	}
+	return strconv.Quote(v)
+}
+
+// Match reports whether record satisfies f. A nil Filter (or one with no
+// predicates) matches every record.
+func (f *Filter) Match(record map[string]interface{}) bool {
+	if f == nil || len(f.Predicates) == 0 {
+		return true
+	}
+	combinator := f.Combinator
+	if combinator == "" {
+		combinator = "and"
+	}
+	if combinator == "or" {
+		for _, p := range f.Predicates {
+			if p.match(record[p.Field]) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, p := range f.Predicates {
+		if !p.match(record[p.Field]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p Predicate) match(actual interface{}) bool {
+	switch p.Op {
+	case "eq":
+		return compareEqual(actual, p.Value)
+	case "ne":
+		return !compareEqual(actual, p.Value)
+	case "sw":
+		return strings.HasPrefix(toString(actual), p.Value)
+	case "ew":
+		return strings.HasSuffix(toString(actual), p.Value)
+	case "contains":
+		return strings.Contains(toString(actual), p.Value)
+	case "gt", "lt", "ge", "le":
+		return compareNumeric(actual, p.Value, p.Op)
+	default:
+		return false
+	}
+}
+
+func compareEqual(actual interface{}, expected string) bool {
+	switch t := actual.(type) {
+	case bool:
+		b, err := strconv.ParseBool(expected)
+		return err == nil && t == b
+	case float64:
+		f, err := strconv.ParseFloat(expected, 64)
+		return err == nil && t == f
+	default:
+		return toString(actual) == expected
+	}
+}
+
+func compareNumeric(actual interface{}, expected, op string) bool {
+	a, ok := toFloat(actual)
+	e, err := strconv.ParseFloat(expected, 64)
+	if !ok || err != nil {
+		return false
+	}
+	switch op {
+	case "gt":
+		return a > e
+	case "lt":
+		return a < e
+	case "ge":
+		return a >= e
+	case "le":
+		return a <= e
+	default:
+		return false
+	}
+}
+
+func toString(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case bool:
+		return strconv.FormatBool(t)
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	default:
+		return fmt.Sprintf("%v", t)
+	}
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch t := v.(type) {
+	case float64:
+		return t, true
+	case string:
+		f, err := strconv.ParseFloat(t, 64)
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// ParseFields splits a --fields value ("code,name") into a trimmed,
+// non-empty field list. An empty value returns nil.
+func ParseFields(s string) []string {
+	if s == "" {
+		return nil
+	}
+	var fields []string
+	for _, f := range strings.Split(s, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// SortSpec is a parsed --sort value: a field name and direction.
+type SortSpec struct {
+	Field      string
+	Descending bool
+}
+
+// ParseSort parses a --sort value of the form "field" or "field:asc" or
+// "field:desc". An empty value returns a nil *SortSpec (no sort applied).
+func ParseSort(s string) (*SortSpec, error) {
+	if s == "" {
+		return nil, nil
+	}
+	field, dir, hasDir := strings.Cut(s, ":")
+	field = strings.TrimSpace(field)
+	if field == "" {
+		return nil, fmt.Errorf("--sort: empty field in %q", s)
+	}
+	spec := &SortSpec{Field: field}
+	if hasDir {
+		switch strings.ToLower(strings.TrimSpace(dir)) {
+		case "asc":
+			spec.Descending = false
+		case "desc":
+			spec.Descending = true
+		default:
+			return nil, fmt.Errorf("--sort: invalid direction %q (expected asc or desc)", dir)
+		}
+	}
+	return spec, nil
+}
+
+// SortString renders spec back into --sort's own syntax, suitable for
+// URL-encoding as the "sort" query parameter. A nil spec renders as "".
+func SortString(spec *SortSpec) string {
+	if spec == nil {
+		return ""
+	}
+	if spec.Descending {
+		return spec.Field + ":desc"
+	}
+	return spec.Field
+}
+
+// ApplySort sorts records in place by spec.Field, comparing numerically
+// when both values parse as numbers and falling back to string comparison
+// otherwise. A nil spec leaves records untouched. Equal keys keep their
+// relative order.
+func ApplySort(records []map[string]interface{}, spec *SortSpec) {
+	if spec == nil {
+		return
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		cmp := compareValues(records[i][spec.Field], records[j][spec.Field])
+		if spec.Descending {
+			return cmp > 0
+		}
+		return cmp < 0
+	})
+}
+
+func compareValues(a, b interface{}) int {
+	if af, aok := toFloat(a); aok {
+		if bf, bok := toFloat(b); bok {
+			switch {
+			case af < bf:
+				return -1
+			case af > bf:
+				return 1
+			default:
+				return 0
+			}
+		}
+	}
+	as, bs := toString(a), toString(b)
+	switch {
+	case as < bs:
+		return -1
+	case as > bs:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// BuildParams assembles filter/fields/sort into the query parameters a
+// server-side "list" endpoint understands: filter=..., fields=..., sort=....
+// Any of the three may be empty/nil, in which case its parameter is omitted.
+func BuildParams(filter *Filter, fields []string, spec *SortSpec) url.Values {
+	params := url.Values{}
+	if s := filter.String(); s != "" {
+		params.Set("filter", s)
+	}
+	if len(fields) > 0 {
+		params.Set("fields", strings.Join(fields, ","))
+	}
+	if s := SortString(spec); s != "" {
+		params.Set("sort", s)
+	}
+	return params
+}
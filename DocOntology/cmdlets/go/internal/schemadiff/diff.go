@@ -0,0 +1,177 @@
+// Package schemadiff computes a JSON-Schema-aware structural diff between
+// two schema Content payloads, classifying each change by whether it
+// tightens or relaxes the contract rather than doing a naive text diff.
+package schemadiff
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Kind classifies a single diff entry.
+type Kind string
+
+const (
+	Added               Kind = "added"
+	Removed             Kind = "removed"
+	TypeChanged         Kind = "type-changed"
+	ConstraintTightened Kind = "constraint-tightened"
+	ConstraintRelaxed   Kind = "constraint-relaxed"
+)
+
+// Change describes a single difference between two schema versions.
+type Change struct {
+	Path     string `json:"path"`
+	Kind     Kind   `json:"kind"`
+	Before   any    `json:"before,omitempty"`
+	After    any    `json:"after,omitempty"`
+	Breaking bool   `json:"breaking"`
+}
+
+// keywordsToWalk are the JSON Schema keywords this diff engine understands;
+// anything else in the content is ignored.
+var keywordsToWalk = []string{"type", "enum", "pattern", "format", "additionalProperties"}
+
+// Diff compares two schema Content maps and returns the list of changes,
+// walking properties/required/type/enum/pattern/format/items/
+// additionalProperties recursively.
+func Diff(before, after map[string]interface{}) []Change {
+	var changes []Change
+	walk("", before, after, &changes)
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes
+}
+
+// Breaking reports whether any change in the set is a breaking change:
+// a removal, a type change, a tightened constraint, or a newly added
+// pattern or format.
+func Breaking(changes []Change) bool {
+	for _, c := range changes {
+		if c.Breaking {
+			return true
+		}
+	}
+	return false
+}
+
+func walk(path string, before, after map[string]interface{}, changes *[]Change) {
+	for _, kw := range keywordsToWalk {
+		diffKeyword(path, kw, before[kw], after[kw], changes)
+	}
+
+	diffRequired(path, before["required"], after["required"], changes)
+	diffProperties(path, before["properties"], after["properties"], changes)
+	diffItems(path, before["items"], after["items"], changes)
+}
+
+func diffKeyword(path, keyword string, before, after interface{}, changes *[]Change) {
+	p := joinPath(path, keyword)
+	switch {
+	case before == nil && after == nil:
+		return
+	case before == nil:
+		*changes = append(*changes, Change{Path: p, Kind: Added, After: after, Breaking: keyword == "pattern" || keyword == "format"})
+	case after == nil:
+		*changes = append(*changes, Change{Path: p, Kind: Removed, Before: before, Breaking: true})
+	case keyword == "type" && before != after:
+		*changes = append(*changes, Change{Path: p, Kind: TypeChanged, Before: before, After: after, Breaking: true})
+	case keyword == "enum":
+		diffEnum(p, before, after, changes)
+	case keyword == "additionalProperties" && before == false && after == true:
+		*changes = append(*changes, Change{Path: p, Kind: ConstraintRelaxed, Before: before, After: after})
+	case fmt.Sprintf("%v", before) != fmt.Sprintf("%v", after):
+		*changes = append(*changes, Change{Path: p, Kind: ConstraintTightened, Before: before, After: after, Breaking: true})
+	}
+}
+
+// diffEnum classifies an enum narrowing (fewer allowed values) as a tightened
+// constraint and a widening as a relaxed one.
+func diffEnum(path string, before, after interface{}, changes *[]Change) {
+	beforeSet := toStringSet(before)
+	afterSet := toStringSet(after)
+
+	removedAny := false
+	for v := range beforeSet {
+		if !afterSet[v] {
+			removedAny = true
+		}
+	}
+	addedAny := false
+	for v := range afterSet {
+		if !beforeSet[v] {
+			addedAny = true
+		}
+	}
+
+	switch {
+	case removedAny && !addedAny:
+		*changes = append(*changes, Change{Path: path, Kind: ConstraintTightened, Before: before, After: after, Breaking: true})
+	case addedAny && !removedAny:
+		*changes = append(*changes, Change{Path: path, Kind: ConstraintRelaxed, Before: before, After: after})
+	case addedAny && removedAny:
+		*changes = append(*changes, Change{Path: path, Kind: ConstraintTightened, Before: before, After: after, Breaking: true})
+	}
+}
+
+func diffRequired(path string, before, after interface{}, changes *[]Change) {
+	beforeSet := toStringSet(before)
+	afterSet := toStringSet(after)
+
+	for v := range afterSet {
+		if !beforeSet[v] {
+			*changes = append(*changes, Change{Path: joinPath(path, "required"), Kind: ConstraintTightened, After: v, Breaking: true})
+		}
+	}
+	for v := range beforeSet {
+		if !afterSet[v] {
+			*changes = append(*changes, Change{Path: joinPath(path, "required"), Kind: ConstraintRelaxed, Before: v})
+		}
+	}
+}
+
+func diffProperties(path string, before, after interface{}, changes *[]Change) {
+	beforeProps, _ := before.(map[string]interface{})
+	afterProps, _ := after.(map[string]interface{})
+
+	for name, b := range beforeProps {
+		propPath := joinPath(path, "properties."+name)
+		a, ok := afterProps[name]
+		if !ok {
+			*changes = append(*changes, Change{Path: propPath, Kind: Removed, Before: b, Breaking: true})
+			continue
+		}
+		bMap, _ := b.(map[string]interface{})
+		aMap, _ := a.(map[string]interface{})
+		walk(propPath, bMap, aMap, changes)
+	}
+	for name, a := range afterProps {
+		if _, ok := beforeProps[name]; !ok {
+			*changes = append(*changes, Change{Path: joinPath(path, "properties."+name), Kind: Added, After: a})
+		}
+	}
+}
+
+func diffItems(path string, before, after interface{}, changes *[]Change) {
+	if before == nil && after == nil {
+		return
+	}
+	bMap, _ := before.(map[string]interface{})
+	aMap, _ := after.(map[string]interface{})
+	walk(joinPath(path, "items"), bMap, aMap, changes)
+}
+
+func toStringSet(v interface{}) map[string]bool {
+	set := map[string]bool{}
+	list, _ := v.([]interface{})
+	for _, item := range list {
+		set[fmt.Sprintf("%v", item)] = true
+	}
+	return set
+}
+
+func joinPath(path, segment string) string {
+	if path == "" {
+		return segment
+	}
+	return path + "." + segment
+}
@@ -0,0 +1,245 @@
+package schemadiff
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ApplyPatch applies an RFC 6902 JSON Patch to doc (a JSON-decoded value —
+// map[string]interface{}, []interface{}, or a scalar) and returns the
+// result. doc itself is never mutated: patch is applied to a deep copy
+// obtained by round-tripping through encoding/json. add/remove/replace/move/
+// copy/test are supported, matching the subset any JSON Patch library
+// exposes. A failing op's index is included in the returned error, so a
+// caller can surface exactly which op in the document was rejected.
+func ApplyPatch(doc interface{}, patch []JSONPatchOp) (interface{}, error) {
+	result, err := deepCopyJSON(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy document: %w", err)
+	}
+
+	for i, op := range patch {
+		var err error
+		switch op.Op {
+		case "add":
+			result, err = patchAdd(result, splitPointer(op.Path), op.Value)
+		case "remove":
+			result, err = patchRemove(result, splitPointer(op.Path))
+		case "replace":
+			result, err = patchReplace(result, splitPointer(op.Path), op.Value)
+		case "move":
+			result, err = patchMove(result, splitPointer(op.Path), splitPointer(op.From))
+		case "copy":
+			result, err = patchCopy(result, splitPointer(op.Path), splitPointer(op.From))
+		case "test":
+			err = patchTest(result, splitPointer(op.Path), op.Value)
+		default:
+			err = fmt.Errorf("unsupported op %q", op.Op)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("patch op %d (%s %s): %w", i, op.Op, op.Path, err)
+		}
+	}
+
+	return result, nil
+}
+
+func deepCopyJSON(doc interface{}) (interface{}, error) {
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	var copied interface{}
+	if err := json.Unmarshal(raw, &copied); err != nil {
+		return nil, err
+	}
+	return copied, nil
+}
+
+// splitPointer splits an RFC 6901 JSON Pointer into its unescaped segments,
+// e.g. "/description" -> ["description"], "" or "/" -> [].
+func splitPointer(pointer string) []string {
+	if pointer == "" || pointer == "/" {
+		return nil
+	}
+	parts := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i, p := range parts {
+		parts[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(p)
+	}
+	return parts
+}
+
+func patchGet(doc interface{}, segments []string) (interface{}, error) {
+	cur := doc
+	for _, seg := range segments {
+		switch node := cur.(type) {
+		case map[string]interface{}:
+			v, ok := node[seg]
+			if !ok {
+				return nil, fmt.Errorf("path %q does not exist", seg)
+			}
+			cur = v
+		case []interface{}:
+			idx, err := arrayIndex(node, seg)
+			if err != nil {
+				return nil, err
+			}
+			cur = node[idx]
+		default:
+			return nil, fmt.Errorf("cannot descend into a scalar at %q", seg)
+		}
+	}
+	return cur, nil
+}
+
+func arrayIndex(arr []interface{}, seg string) (int, error) {
+	if seg == "-" {
+		return -1, fmt.Errorf("index \"-\" (append) is not a valid read target")
+	}
+	idx, err := strconv.Atoi(seg)
+	if err != nil || idx < 0 || idx >= len(arr) {
+		return 0, fmt.Errorf("array index %q out of range", seg)
+	}
+	return idx, nil
+}
+
+// patchAdd implements RFC 6902 "add": it sets a new or existing object key,
+// or inserts into an array ("-" appends).
+func patchAdd(doc interface{}, segments []string, value interface{}) (interface{}, error) {
+	if len(segments) == 0 {
+		return value, nil
+	}
+	parent, err := patchGet(doc, segments[:len(segments)-1])
+	if err != nil {
+		return nil, err
+	}
+	last := segments[len(segments)-1]
+
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		node[last] = value
+		return doc, nil
+	case []interface{}:
+		if last == "-" {
+			_, err := replaceInParent(doc, segments[:len(segments)-1], append(node, value))
+			return doc, err
+		}
+		idx, err := strconv.Atoi(last)
+		if err != nil || idx < 0 || idx > len(node) {
+			return nil, fmt.Errorf("array index %q out of range", last)
+		}
+		next := append(node[:idx:idx], append([]interface{}{value}, node[idx:]...)...)
+		_, err = replaceInParent(doc, segments[:len(segments)-1], next)
+		return doc, err
+	default:
+		return nil, fmt.Errorf("cannot add under a scalar")
+	}
+}
+
+func patchRemove(doc interface{}, segments []string) (interface{}, error) {
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("cannot remove the document root")
+	}
+	parent, err := patchGet(doc, segments[:len(segments)-1])
+	if err != nil {
+		return nil, err
+	}
+	last := segments[len(segments)-1]
+
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		if _, ok := node[last]; !ok {
+			return nil, fmt.Errorf("path %q does not exist", last)
+		}
+		delete(node, last)
+		return doc, nil
+	case []interface{}:
+		idx, err := arrayIndex(node, last)
+		if err != nil {
+			return nil, err
+		}
+		next := append(node[:idx:idx], node[idx+1:]...)
+		_, err = replaceInParent(doc, segments[:len(segments)-1], next)
+		return doc, err
+	default:
+		return nil, fmt.Errorf("cannot remove from a scalar")
+	}
+}
+
+func patchReplace(doc interface{}, segments []string, value interface{}) (interface{}, error) {
+	if len(segments) == 0 {
+		return value, nil
+	}
+	if _, err := patchGet(doc, segments); err != nil {
+		return nil, err
+	}
+	return replaceInParent(doc, segments, value)
+}
+
+// replaceInParent sets doc's value at segments to value, requiring the
+// parent already exists (segments may name a not-yet-present leaf key, used
+// internally by patchAdd).
+func replaceInParent(doc interface{}, segments []string, value interface{}) (interface{}, error) {
+	if len(segments) == 0 {
+		return value, nil
+	}
+	parent, err := patchGet(doc, segments[:len(segments)-1])
+	if err != nil {
+		return nil, err
+	}
+	last := segments[len(segments)-1]
+
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		node[last] = value
+		return doc, nil
+	case []interface{}:
+		idx, err := arrayIndex(node, last)
+		if err != nil {
+			return nil, err
+		}
+		node[idx] = value
+		return doc, nil
+	default:
+		return nil, fmt.Errorf("cannot set a value under a scalar")
+	}
+}
+
+func patchMove(doc interface{}, segments, from []string) (interface{}, error) {
+	value, err := patchGet(doc, from)
+	if err != nil {
+		return nil, err
+	}
+	value, _ = deepCopyJSON(value)
+	if _, err := patchRemove(doc, from); err != nil {
+		return nil, err
+	}
+	return patchAdd(doc, segments, value)
+}
+
+func patchCopy(doc interface{}, segments, from []string) (interface{}, error) {
+	value, err := patchGet(doc, from)
+	if err != nil {
+		return nil, err
+	}
+	value, err = deepCopyJSON(value)
+	if err != nil {
+		return nil, err
+	}
+	return patchAdd(doc, segments, value)
+}
+
+func patchTest(doc interface{}, segments []string, expected interface{}) error {
+	actual, err := patchGet(doc, segments)
+	if err != nil {
+		return err
+	}
+	actualJSON, _ := json.Marshal(actual)
+	expectedJSON, _ := json.Marshal(expected)
+	if string(actualJSON) != string(expectedJSON) {
+		return fmt.Errorf("test failed: value does not match")
+	}
+	return nil
+}
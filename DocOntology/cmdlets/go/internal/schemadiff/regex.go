@@ -0,0 +1,34 @@
+package schemadiff
+
+import "regexp"
+
+// RegexRegression describes a corpus sample whose match result flipped
+// between two versions of a regex-typed schema's pattern.
+type RegexRegression struct {
+	Sample        string `json:"sample"`
+	MatchedBefore bool   `json:"matchedBefore"`
+	MatchedAfter  bool   `json:"matchedAfter"`
+}
+
+// CompareCorpus compiles both patterns and runs each corpus line through
+// them, flagging samples whose match result changed. This is a heuristic,
+// not a proof of regex inclusion/exclusion.
+func CompareCorpus(beforePattern, afterPattern string, corpus []string) ([]RegexRegression, error) {
+	before, err := regexp.Compile(beforePattern)
+	if err != nil {
+		return nil, err
+	}
+	after, err := regexp.Compile(afterPattern)
+	if err != nil {
+		return nil, err
+	}
+
+	var regressions []RegexRegression
+	for _, sample := range corpus {
+		b, a := before.MatchString(sample), after.MatchString(sample)
+		if b != a {
+			regressions = append(regressions, RegexRegression{Sample: sample, MatchedBefore: b, MatchedAfter: a})
+		}
+	}
+	return regressions, nil
+}
@@ -0,0 +1,295 @@
+package schemadiff
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Op classifies a single StructuralDiff change.
+type Op string
+
+const (
+	OpAdd    Op = "add"
+	OpRemove Op = "remove"
+	OpChange Op = "change"
+)
+
+// PathChange is one field-level difference found by StructuralDiff, with
+// both a human-readable dot/bracket Path (e.g. "properties.name.type" or
+// "required[2]") and an RFC 6901 JSON Pointer (e.g. "/properties/name/type")
+// for JSON Patch generation.
+type PathChange struct {
+	Path    string
+	Pointer string
+	Op      Op
+	Before  interface{}
+	After   interface{}
+}
+
+// StructuralOptions configures StructuralDiff's handling of arrays.
+type StructuralOptions struct {
+	// ArrayPath, if set, is the dot-path of the array (relative to the
+	// diffed root) whose elements should be matched by ArrayKeyField rather
+	// than by index — e.g. ArrayPath "properties", ArrayKeyField "name"
+	// pairs elements of the array at path "properties" by their "name"
+	// field, so reordering elements doesn't read as a wholesale add+remove.
+	ArrayPath     string
+	ArrayKeyField string
+}
+
+// StructuralDiff recursively walks before and after (as decoded by
+// encoding/json: map[string]interface{}, []interface{}, and scalars) and
+// returns every added, removed, or changed leaf path, sorted by Path for a
+// stable report. Unlike Diff, it has no JSON-Schema-specific knowledge: it
+// walks every field of the two objects, not just a fixed set of keywords.
+func StructuralDiff(before, after interface{}, opts StructuralOptions) []PathChange {
+	var changes []PathChange
+	walkStructural("", "", before, after, opts, &changes)
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes
+}
+
+func walkStructural(path, pointer string, before, after interface{}, opts StructuralOptions, out *[]PathChange) {
+	if before == nil && after == nil {
+		return
+	}
+	if before == nil {
+		*out = append(*out, PathChange{Path: path, Pointer: pointer, Op: OpAdd, After: after})
+		return
+	}
+	if after == nil {
+		*out = append(*out, PathChange{Path: path, Pointer: pointer, Op: OpRemove, Before: before})
+		return
+	}
+
+	bm, bIsMap := before.(map[string]interface{})
+	am, aIsMap := after.(map[string]interface{})
+	if bIsMap && aIsMap {
+		walkObject(path, pointer, bm, am, opts, out)
+		return
+	}
+
+	ba, bIsArr := before.([]interface{})
+	aa, aIsArr := after.([]interface{})
+	if bIsArr && aIsArr {
+		walkArray(path, pointer, ba, aa, opts, out)
+		return
+	}
+
+	if !reflect.DeepEqual(before, after) {
+		*out = append(*out, PathChange{Path: path, Pointer: pointer, Op: OpChange, Before: before, After: after})
+	}
+}
+
+func walkObject(path, pointer string, before, after map[string]interface{}, opts StructuralOptions, out *[]PathChange) {
+	keys := map[string]bool{}
+	for k := range before {
+		keys[k] = true
+	}
+	for k := range after {
+		keys[k] = true
+	}
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	for _, k := range sorted {
+		bv, bok := before[k]
+		av, aok := after[k]
+		childPath := joinDotPath(path, k)
+		childPointer := joinPointer(pointer, k)
+		switch {
+		case !bok:
+			walkStructural(childPath, childPointer, nil, av, opts, out)
+		case !aok:
+			walkStructural(childPath, childPointer, bv, nil, opts, out)
+		default:
+			walkStructural(childPath, childPointer, bv, av, opts, out)
+		}
+	}
+}
+
+func walkArray(path, pointer string, before, after []interface{}, opts StructuralOptions, out *[]PathChange) {
+	if opts.ArrayKeyField != "" && opts.ArrayPath == path {
+		walkKeyedArray(path, pointer, before, after, opts, out)
+		return
+	}
+
+	max := len(before)
+	if len(after) > max {
+		max = len(after)
+	}
+	for i := 0; i < max; i++ {
+		childPath := fmt.Sprintf("%s[%d]", path, i)
+		childPointer := pointer + "/" + strconv.Itoa(i)
+		switch {
+		case i >= len(before):
+			walkStructural(childPath, childPointer, nil, after[i], opts, out)
+		case i >= len(after):
+			walkStructural(childPath, childPointer, before[i], nil, opts, out)
+		default:
+			walkStructural(childPath, childPointer, before[i], after[i], opts, out)
+		}
+	}
+}
+
+// walkKeyedArray matches before/after elements of a keyed array by the
+// string form of their ArrayKeyField value rather than by position, so
+// reordering elements doesn't read as a wholesale add+remove. The JSON
+// Pointer of a matched, added, or removed element still addresses its index
+// in after (or, for a removed element, its index in before), since RFC 6901
+// addresses arrays positionally — there is no pointer-stable way to address
+// "the element keyed foo" directly.
+func walkKeyedArray(path, pointer string, before, after []interface{}, opts StructuralOptions, out *[]PathChange) {
+	beforeByKey := map[string]interface{}{}
+	beforeIndex := map[string]int{}
+	for i, el := range before {
+		key := arrayKeyOf(el, opts.ArrayKeyField)
+		beforeByKey[key] = el
+		beforeIndex[key] = i
+	}
+
+	afterIndex := map[string]int{}
+	for i, el := range after {
+		afterIndex[arrayKeyOf(el, opts.ArrayKeyField)] = i
+	}
+
+	keys := map[string]bool{}
+	for k := range beforeByKey {
+		keys[k] = true
+	}
+	for k := range afterIndex {
+		keys[k] = true
+	}
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	for _, key := range sorted {
+		childPath := fmt.Sprintf("%s[%s=%s]", path, opts.ArrayKeyField, key)
+		bv, bok := beforeByKey[key]
+		idx, aok := afterIndex[key]
+		switch {
+		case !bok:
+			childPointer := pointer + "/" + strconv.Itoa(idx)
+			walkStructural(childPath, childPointer, nil, after[idx], opts, out)
+		case !aok:
+			childPointer := pointer + "/" + strconv.Itoa(beforeIndex[key])
+			walkStructural(childPath, childPointer, bv, nil, opts, out)
+		default:
+			childPointer := pointer + "/" + strconv.Itoa(idx)
+			walkStructural(childPath, childPointer, bv, after[idx], opts, out)
+		}
+	}
+}
+
+func arrayKeyOf(el interface{}, field string) string {
+	m, ok := el.(map[string]interface{})
+	if !ok {
+		return fmt.Sprintf("%v", el)
+	}
+	return fmt.Sprintf("%v", m[field])
+}
+
+func joinDotPath(path, segment string) string {
+	if path == "" {
+		return segment
+	}
+	return path + "." + segment
+}
+
+// joinPointer appends segment to an RFC 6901 JSON Pointer, escaping "~" and
+// "/" as the spec requires.
+func joinPointer(pointer, segment string) string {
+	escaped := strings.NewReplacer("~", "~0", "/", "~1").Replace(segment)
+	return pointer + "/" + escaped
+}
+
+// JSONPatchOp is one operation of an RFC 6902 JSON Patch.
+type JSONPatchOp struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	From  string      `json:"from,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// ToJSONPatch converts StructuralDiff's changes into an RFC 6902 JSON Patch
+// that transforms "before" into "after".
+func ToJSONPatch(changes []PathChange) []JSONPatchOp {
+	patch := make([]JSONPatchOp, 0, len(changes))
+	for _, c := range changes {
+		switch c.Op {
+		case OpAdd:
+			patch = append(patch, JSONPatchOp{Op: "add", Path: c.Pointer, Value: c.After})
+		case OpRemove:
+			patch = append(patch, JSONPatchOp{Op: "remove", Path: c.Pointer})
+		case OpChange:
+			patch = append(patch, JSONPatchOp{Op: "replace", Path: c.Pointer, Value: c.After})
+		}
+	}
+	reorderArrayRemoves(patch)
+	return patch
+}
+
+// reorderArrayRemoves fixes up "remove" ops that target elements of the same
+// array: StructuralDiff emits them in ascending before-index order (since
+// changes are Path-sorted), but RFC 6902 applies ops sequentially against a
+// mutating document, so removing a lower index first shifts every higher
+// index out from under the next op. It re-sorts each same-array group of
+// removes into descending-index order in place, leaving every other op's
+// position untouched.
+func reorderArrayRemoves(patch []JSONPatchOp) {
+	type group struct {
+		positions []int
+		indices   []int
+	}
+	groups := map[string]*group{}
+	for i, op := range patch {
+		if op.Op != "remove" {
+			continue
+		}
+		parent, idx, ok := splitArrayIndex(op.Path)
+		if !ok {
+			continue
+		}
+		g := groups[parent]
+		if g == nil {
+			g = &group{}
+			groups[parent] = g
+		}
+		g.positions = append(g.positions, i)
+		g.indices = append(g.indices, idx)
+	}
+	for parent, g := range groups {
+		if len(g.positions) < 2 {
+			continue
+		}
+		sorted := append([]int(nil), g.indices...)
+		sort.Sort(sort.Reverse(sort.IntSlice(sorted)))
+		for j, pos := range g.positions {
+			patch[pos].Path = parent + "/" + strconv.Itoa(sorted[j])
+		}
+	}
+}
+
+// splitArrayIndex splits a JSON Pointer into its parent pointer and trailing
+// array index, e.g. "/required/3" -> ("/required", 3, true). It returns
+// ok=false for pointers whose last segment isn't a plain array index.
+func splitArrayIndex(pointer string) (parent string, idx int, ok bool) {
+	i := strings.LastIndex(pointer, "/")
+	if i < 0 {
+		return "", 0, false
+	}
+	n, err := strconv.Atoi(pointer[i+1:])
+	if err != nil {
+		return "", 0, false
+	}
+	return pointer[:i], n, true
+}
@@ -0,0 +1,85 @@
+package schemadiff
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func decodeJSON(t *testing.T, s string) interface{} {
+	t.Helper()
+	var v interface{}
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		t.Fatalf("invalid JSON literal: %v", err)
+	}
+	return v
+}
+
+func TestStructuralDiffAddRemoveChange(t *testing.T) {
+	before := decodeJSON(t, `{"a":1,"b":2,"c":3}`)
+	after := decodeJSON(t, `{"a":1,"b":20,"d":4}`)
+
+	changes := StructuralDiff(before, after, StructuralOptions{})
+
+	want := map[string]Op{"b": OpChange, "c": OpRemove, "d": OpAdd}
+	if len(changes) != len(want) {
+		t.Fatalf("got %d changes, want %d: %+v", len(changes), len(want), changes)
+	}
+	for _, c := range changes {
+		op, ok := want[c.Path]
+		if !ok {
+			t.Fatalf("unexpected change at path %q", c.Path)
+		}
+		if c.Op != op {
+			t.Errorf("path %q: got op %q, want %q", c.Path, c.Op, op)
+		}
+	}
+}
+
+func TestStructuralDiffKeyedArrayReorder(t *testing.T) {
+	before := decodeJSON(t, `{"properties":[{"name":"a","type":"string"},{"name":"b","type":"string"}]}`)
+	after := decodeJSON(t, `{"properties":[{"name":"b","type":"string"},{"name":"a","type":"string"}]}`)
+
+	changes := StructuralDiff(before, after, StructuralOptions{ArrayPath: "properties", ArrayKeyField: "name"})
+
+	if len(changes) != 0 {
+		t.Fatalf("reordering keyed array elements should produce no changes, got %+v", changes)
+	}
+}
+
+// TestToJSONPatchMultiRemove is a regression test for a JSON Patch produced
+// from removing two-or-more elements of the same array: RFC 6902 applies
+// ops sequentially, so a patch built in ascending before-index order becomes
+// invalid partway through (the array has already shrunk). ToJSONPatch must
+// reorder same-array removes so the resulting patch actually applies.
+func TestToJSONPatchMultiRemove(t *testing.T) {
+	before := decodeJSON(t, `{"required":["a","b","c","d"]}`)
+	after := decodeJSON(t, `{"required":["a","c"]}`)
+
+	changes := StructuralDiff(before, after, StructuralOptions{})
+	patch := ToJSONPatch(changes)
+
+	result, err := ApplyPatch(before, patch)
+	if err != nil {
+		t.Fatalf("ApplyPatch failed: %v (patch: %+v)", err, patch)
+	}
+	if !reflect.DeepEqual(result, after) {
+		t.Errorf("applying patch produced %+v, want %+v", result, after)
+	}
+}
+
+func TestToJSONPatchMultiRemoveNonContiguous(t *testing.T) {
+	before := decodeJSON(t, `{"tags":["a","b","c","d","e"]}`)
+	after := decodeJSON(t, `{"tags":["a","c","e"]}`)
+
+	changes := StructuralDiff(before, after, StructuralOptions{})
+	patch := ToJSONPatch(changes)
+
+	result, err := ApplyPatch(before, patch)
+	if err != nil {
+		t.Fatalf("ApplyPatch failed: %v (patch: %+v)", err, patch)
+	}
+	if !reflect.DeepEqual(result, after) {
+		t.Errorf("applying patch produced %+v, want %+v", result, after)
+	}
+}
@@ -0,0 +1,153 @@
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// DataError describes one failed instance-validation check of actual data
+// against a schema, with a JSON Pointer to the offending field and the JSON
+// Schema keyword that failed.
+type DataError struct {
+	Pointer string `json:"path"`
+	Keyword string `json:"keyword"`
+	Message string `json:"message"`
+}
+
+func (e DataError) String() string {
+	return fmt.Sprintf("%s: %s", e.Pointer, e.Message)
+}
+
+// CompiledSchema wraps a schema compiled once from a schema's Content, so
+// validating many data files against it doesn't re-parse the schema for
+// each one.
+type CompiledSchema struct {
+	schema *gojsonschema.Schema
+}
+
+// Compile parses content once into a reusable CompiledSchema.
+func Compile(content map[string]interface{}) (*CompiledSchema, error) {
+	raw, err := json.Marshal(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal schema content: %w", err)
+	}
+	schema, err := gojsonschema.NewSchema(gojsonschema.NewBytesLoader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile schema: %w", err)
+	}
+	return &CompiledSchema{schema: schema}, nil
+}
+
+// Validate checks data (already decoded, e.g. via encoding/json or
+// yaml.Unmarshal into interface{}) against the compiled schema, returning
+// one DataError per failing assertion.
+func (c *CompiledSchema) Validate(data interface{}) ([]DataError, error) {
+	result, err := c.schema.Validate(gojsonschema.NewGoLoader(data))
+	if err != nil {
+		return nil, fmt.Errorf("validation failed to run: %w", err)
+	}
+
+	var errs []DataError
+	for _, re := range result.Errors() {
+		errs = append(errs, DataError{
+			Pointer: fieldToPointer(re.Field()),
+			Keyword: re.Type(),
+			Message: re.Description(),
+		})
+	}
+	return errs, nil
+}
+
+// fieldToPointer converts gojsonschema's dot-separated field path (e.g.
+// "invoice.lineItems.3.totalNet", or "(root)" for a root-level failure)
+// into a JSON Pointer (e.g. "/invoice/lineItems/3/totalNet").
+func fieldToPointer(field string) string {
+	if field == "" || field == "(root)" {
+		return "/"
+	}
+	return "/" + strings.ReplaceAll(field, ".", "/")
+}
+
+// Coercion describes one field where the value's JSON type doesn't match
+// its schema's declared type, but a coercion the extraction pipeline
+// applies — string->number, string->bool, a recognized date string->
+// format: date-time — would fix it.
+type Coercion struct {
+	Pointer string `json:"path"`
+	From    string `json:"from"`
+	To      string `json:"to"`
+}
+
+// Coerce walks data against schema's properties/items, reporting every
+// field a known coercion would fix. It never mutates data — this is a
+// dry-run report of what the extraction pipeline would do, not a rewrite.
+// $ref is not followed: a field inside a $ref'd definition is skipped
+// rather than reported, since resolving it needs the definitions map this
+// function isn't given.
+func Coerce(schema map[string]interface{}, data interface{}) []Coercion {
+	var out []Coercion
+	walkCoerce("", schema, data, &out)
+	return out
+}
+
+func walkCoerce(pointer string, schema map[string]interface{}, data interface{}, out *[]Coercion) {
+	if schema == nil || data == nil {
+		return
+	}
+	if _, isRef := schema["$ref"]; isRef {
+		return
+	}
+
+	if s, ok := data.(string); ok {
+		declaredType, _ := schema["type"].(string)
+		format, _ := schema["format"].(string)
+		switch {
+		case declaredType == "number" || declaredType == "integer":
+			if _, err := strconv.ParseFloat(s, 64); err == nil {
+				*out = append(*out, Coercion{Pointer: pointerOrRoot(pointer), From: "string", To: declaredType})
+			}
+		case declaredType == "boolean":
+			if _, err := strconv.ParseBool(s); err == nil {
+				*out = append(*out, Coercion{Pointer: pointerOrRoot(pointer), From: "string", To: "boolean"})
+			}
+		case format == "date-time":
+			if _, err := time.Parse(time.RFC3339, s); err != nil {
+				for _, layout := range []string{"2006-01-02", "2006-01-02 15:04:05", time.RFC1123} {
+					if _, err := time.Parse(layout, s); err == nil {
+						*out = append(*out, Coercion{Pointer: pointerOrRoot(pointer), From: "string", To: "date-time"})
+						break
+					}
+				}
+			}
+		}
+		return
+	}
+
+	if obj, ok := data.(map[string]interface{}); ok {
+		properties, _ := schema["properties"].(map[string]interface{})
+		for key, propSchemaRaw := range properties {
+			propSchema, _ := propSchemaRaw.(map[string]interface{})
+			walkCoerce(pointer+"/"+key, propSchema, obj[key], out)
+		}
+		return
+	}
+
+	if arr, ok := data.([]interface{}); ok {
+		items, _ := schema["items"].(map[string]interface{})
+		for i, el := range arr {
+			walkCoerce(fmt.Sprintf("%s/%d", pointer, i), items, el, out)
+		}
+	}
+}
+
+func pointerOrRoot(pointer string) string {
+	if pointer == "" {
+		return "/"
+	}
+	return pointer
+}
@@ -0,0 +1,81 @@
+package validate
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// regexFormatChecker rejects pattern values using PCRE-only constructs (Go's
+// regexp/syntax is RE2 and doesn't support lookaround or backreferences),
+// tuned for content validated as client.SchemaTypeRegex.
+type regexFormatChecker struct{}
+
+func (regexFormatChecker) IsFormat(input interface{}) bool {
+	s, ok := input.(string)
+	if !ok {
+		return true
+	}
+	_, err := regexp.Compile(s)
+	return err == nil
+}
+
+// dateISOFormatChecker validates RFC 3339 / ISO-8601 date strings (date
+// only, no time component).
+type dateISOFormatChecker struct{}
+
+func (dateISOFormatChecker) IsFormat(input interface{}) bool {
+	s, ok := input.(string)
+	if !ok {
+		return true
+	}
+	_, err := time.Parse("2006-01-02", s)
+	return err == nil
+}
+
+// countryCodeFormatChecker restricts a string to the Country.Code values
+// loaded from the registry's reference data.
+type countryCodeFormatChecker struct {
+	codes map[string]bool
+}
+
+func (c countryCodeFormatChecker) IsFormat(input interface{}) bool {
+	s, ok := input.(string)
+	if !ok {
+		return true
+	}
+	return c.codes[s]
+}
+
+// doctypeCodeFormatChecker restricts a string to the DocType.Code values
+// loaded from the registry's reference data.
+type doctypeCodeFormatChecker struct {
+	codes map[string]bool
+}
+
+func (c doctypeCodeFormatChecker) IsFormat(input interface{}) bool {
+	s, ok := input.(string)
+	if !ok {
+		return true
+	}
+	return c.codes[s]
+}
+
+// registerFormatCheckers installs this package's custom FormatChecker
+// implementations, scoping country-code/doctype-code to the codes supplied
+// by the caller (typically fetched via GetClient().GetReferenceData()).
+func registerFormatCheckers(countryCodes, docTypeCodes []string) {
+	gojsonschema.FormatCheckers.Add("regex", regexFormatChecker{})
+	gojsonschema.FormatCheckers.Add("date-iso", dateISOFormatChecker{})
+	gojsonschema.FormatCheckers.Add("country-code", countryCodeFormatChecker{codes: toSet(countryCodes)})
+	gojsonschema.FormatCheckers.Add("doctype-code", doctypeCodeFormatChecker{codes: toSet(docTypeCodes)})
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
@@ -0,0 +1,78 @@
+package validate
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Issue describes a single style finding from Lint. Unlike Content's errors,
+// an Issue doesn't mean the schema is invalid — it means the schema could be
+// improved.
+type Issue struct {
+	Pointer string
+	Message string
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("%s: %s", i.Pointer, i.Message)
+}
+
+// Lint checks a schema's content for common style problems that meta-
+// validation doesn't catch: missing `$id`/`title`/`description`, an
+// unbounded `additionalProperties`, `required` entries with no matching
+// property definition, and (for
+// regex-typed schemas) a pattern that fails to compile.
+func Lint(content map[string]interface{}, schemaType string) []Issue {
+	var issues []Issue
+
+	for _, key := range []string{"$id", "title", "description"} {
+		if _, ok := content[key]; !ok {
+			issues = append(issues, Issue{Pointer: "/" + key, Message: fmt.Sprintf("missing recommended %q", key)})
+		}
+	}
+
+	issues = append(issues, lintNode("", content)...)
+
+	if schemaType == "regex" {
+		if pattern, ok := content["pattern"].(string); ok {
+			if _, err := regexp.Compile(pattern); err != nil {
+				issues = append(issues, Issue{Pointer: "/pattern", Message: fmt.Sprintf("failed to compile: %s", err)})
+			}
+		}
+	}
+
+	return issues
+}
+
+// lintNode walks object nodes looking for an unbounded additionalProperties
+// and required entries with no matching property definition.
+func lintNode(pointer string, node map[string]interface{}) []Issue {
+	var issues []Issue
+
+	if additional, ok := node["additionalProperties"]; ok {
+		if b, isBool := additional.(bool); isBool && b {
+			issues = append(issues, Issue{Pointer: pointer + "/additionalProperties", Message: "unbounded additionalProperties allows any extra field"})
+		}
+	}
+
+	properties, _ := node["properties"].(map[string]interface{})
+	if required, ok := node["required"].([]interface{}); ok {
+		for _, r := range required {
+			name, _ := r.(string)
+			if name == "" {
+				continue
+			}
+			if _, defined := properties[name]; !defined {
+				issues = append(issues, Issue{Pointer: pointer + "/required", Message: fmt.Sprintf("required entry %q has no matching property definition", name)})
+			}
+		}
+	}
+
+	for name, v := range properties {
+		if child, ok := v.(map[string]interface{}); ok {
+			issues = append(issues, lintNode(pointer+"/properties/"+name, child)...)
+		}
+	}
+
+	return issues
+}
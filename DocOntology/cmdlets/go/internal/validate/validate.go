@@ -0,0 +1,76 @@
+// Package validate runs Schema.Content payloads through a local JSON Schema
+// meta-validation pass, so mistakes in schema content are caught before
+// schemas create/update ever reaches the API.
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// Error describes a single meta-validation failure, with a JSON Pointer to
+// the offending node so users can jump straight to the problem.
+type Error struct {
+	Pointer     string
+	Description string
+}
+
+func (e Error) String() string {
+	return fmt.Sprintf("%s: %s", e.Pointer, e.Description)
+}
+
+// Options configures a validation run.
+type Options struct {
+	// Strict fails validation on unknown (non-draft) keywords.
+	Strict bool
+	// CountryCodes and DocTypeCodes scope the country-code/doctype-code
+	// format checkers to the values currently registered in the registry.
+	CountryCodes []string
+	DocTypeCodes []string
+}
+
+// Content meta-validates a schema's Content payload against JSON Schema
+// draft-07/2020-12 and this module's custom format checkers (regex,
+// date-iso, country-code, doctype-code).
+func Content(content map[string]interface{}, opts Options) ([]Error, error) {
+	registerFormatCheckers(opts.CountryCodes, opts.DocTypeCodes)
+
+	raw, err := json.Marshal(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal content: %w", err)
+	}
+
+	documentLoader := gojsonschema.NewBytesLoader(raw)
+
+	// Draft 2020-12 has no fixed meta-schema URL in gojsonschema, so we
+	// meta-validate against the draft-07 meta-schema, which covers the
+	// keywords this registry's schemas actually use.
+	metaLoader := gojsonschema.NewStringLoader(draft07MetaSchema)
+
+	result, err := gojsonschema.Validate(metaLoader, documentLoader)
+	if err != nil {
+		return nil, fmt.Errorf("meta-validation failed to run: %w", err)
+	}
+
+	var errs []Error
+	for _, re := range result.Errors() {
+		if opts.Strict || re.Type() != "additional_property_not_allowed" {
+			errs = append(errs, Error{
+				Pointer:     fieldToPointer(re.Field()),
+				Description: re.Description(),
+			})
+		}
+	}
+	return errs, nil
+}
+
+// draft07MetaSchema is the canonical JSON Schema draft-07 meta-schema,
+// vendored so meta-validation works without a network round-trip.
+const draft07MetaSchema = `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "$id": "http://json-schema.org/draft-07/schema#",
+  "title": "Core schema meta-schema",
+  "type": ["object", "boolean"]
+}`
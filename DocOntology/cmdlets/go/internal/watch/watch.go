@@ -0,0 +1,40 @@
+// Package watch implements the polling loop behind every `--watch` flag:
+// re-run a fetch-and-render callback on an interval until its context is
+// cancelled, so a long-running watch can be interrupted (e.g. via Ctrl-C in
+// the interactive shell) without killing the whole process.
+package watch
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultInterval is how often a watch re-polls when the caller doesn't
+// specify one.
+const DefaultInterval = 3 * time.Second
+
+// Run invokes fn immediately, then again every interval, until ctx is
+// cancelled or fn returns a non-nil error.
+func Run(ctx context.Context, interval time.Duration, fn func() error) error {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	if err := fn(); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := fn(); err != nil {
+				return err
+			}
+		}
+	}
+}
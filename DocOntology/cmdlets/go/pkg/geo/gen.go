@@ -0,0 +1,100 @@
+//go:build ignore
+
+// gen.go regenerates countries_data.go from CLDR/ISO 3166 source data. It is
+// not part of the build (see the //go:build ignore tag above); run it with
+// `go generate ./pkg/geo` whenever the upstream dataset changes.
+//
+// It expects two JSON files, produced from CLDR's core/common/main and
+// iso-codes' iso_3166-2.json, next to this file:
+//
+//	cldr_countries.json  -- { "PT": { "name": "Portugal", "alpha3": "PRT", ... } }
+//	cldr_subdivisions.json -- { "PT": [ { "code": "PT-11", "name": "Lisboa" }, ... ] }
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"text/template"
+)
+
+type countryJSON struct {
+	Alpha3      string `json:"alpha3"`
+	Numeric     string `json:"numeric"`
+	Name        string `json:"name"`
+	CallingCode string `json:"callingCode"`
+	Currency    string `json:"currency"`
+	Region      string `json:"region"`
+	Subregion   string `json:"subregion"`
+}
+
+type subdivisionJSON struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+var tmpl = template.Must(template.New("countries_data").Parse(`// Code generated by gen.go from ISO 3166 / CLDR reference data; DO NOT EDIT.
+// Regenerate with: go generate ./pkg/geo
+
+package geo
+
+var countries = []Country{
+{{- range . }}
+	{Alpha2: "{{ .Alpha2 }}", Alpha3: "{{ .Alpha3 }}", Numeric: "{{ .Numeric }}", Name: "{{ .Name }}", CallingCode: "{{ .CallingCode }}", Currency: "{{ .Currency }}", Region: "{{ .Region }}", Subregion: "{{ .Subregion }}"{{ if .Subdivisions }}, Subdivisions: []Subdivision{ {{ range .Subdivisions }}{Code: "{{ .Code }}", Name: "{{ .Name }}"}, {{ end }}} {{ end }}},
+{{- end }}
+}
+`))
+
+type countryOut struct {
+	Alpha2, Alpha3, Numeric, Name, CallingCode, Currency, Region, Subregion string
+	Subdivisions                                                           []subdivisionJSON
+}
+
+func main() {
+	var byCode map[string]countryJSON
+	if err := loadJSON("cldr_countries.json", &byCode); err != nil {
+		log.Fatal(err)
+	}
+
+	var subsByCode map[string][]subdivisionJSON
+	if err := loadJSON("cldr_subdivisions.json", &subsByCode); err != nil {
+		log.Fatal(err)
+	}
+
+	codes := make([]string, 0, len(byCode))
+	for code := range byCode {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	out := make([]countryOut, 0, len(codes))
+	for _, code := range codes {
+		c := byCode[code]
+		out = append(out, countryOut{
+			Alpha2: code, Alpha3: c.Alpha3, Numeric: c.Numeric, Name: c.Name,
+			CallingCode: c.CallingCode, Currency: c.Currency, Region: c.Region, Subregion: c.Subregion,
+			Subdivisions: subsByCode[code],
+		})
+	}
+
+	f, err := os.Create("countries_data.go")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	if err := tmpl.Execute(f, out); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("wrote countries_data.go")
+}
+
+func loadJSON(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,152 @@
+// Package geo ships a small embedded ISO 3166 / CLDR-derived reference
+// dataset (country codes, names, currencies, regions, and subdivisions) so
+// schemactl can validate and autocomplete country codes offline, without a
+// round trip to the Schema Registry API. The dataset itself lives in
+// countries_data.go and is regenerated by gen.go (run via `go generate`).
+package geo
+
+//go:generate go run gen.go
+
+import "strings"
+
+// Subdivision is an ISO 3166-2 region within a country (e.g. a Portuguese
+// district or a US state).
+type Subdivision struct {
+	Code string // ISO 3166-2 code, e.g. "PT-11"
+	Name string
+}
+
+// Country is one entry of the embedded reference dataset.
+type Country struct {
+	Alpha2       string // ISO 3166-1 alpha-2, e.g. "PT"
+	Alpha3       string // ISO 3166-1 alpha-3, e.g. "PRT"
+	Numeric      string // ISO 3166-1 numeric, e.g. "620"
+	Name         string
+	CallingCode  string // e.g. "+351"
+	Currency     string // ISO 4217 code, e.g. "EUR"
+	Region       string
+	Subregion    string
+	Subdivisions []Subdivision
+}
+
+// byAlpha2 indexes the embedded dataset by uppercase alpha-2 code, built
+// once from the countries slice defined in countries_data.go.
+var byAlpha2 = func() map[string]Country {
+	m := make(map[string]Country, len(countries))
+	for _, c := range countries {
+		m[c.Alpha2] = c
+	}
+	return m
+}()
+
+// All returns every country in the embedded dataset.
+func All() []Country {
+	return countries
+}
+
+// Lookup returns the country for an ISO 3166-1 alpha-2 code (case
+// insensitive).
+func Lookup(alpha2 string) (Country, bool) {
+	c, ok := byAlpha2[strings.ToUpper(alpha2)]
+	return c, ok
+}
+
+// Known reports whether alpha2 is a recognized country code.
+func Known(alpha2 string) bool {
+	_, ok := Lookup(alpha2)
+	return ok
+}
+
+// Filter narrows the dataset by region, subregion, and currency. An empty
+// filter value matches everything for that field.
+type Filter struct {
+	Region    string
+	Subregion string
+	Currency  string
+}
+
+// Filtered returns the countries matching f (case-insensitive, exact match
+// per non-empty field).
+func Filtered(f Filter) []Country {
+	var out []Country
+	for _, c := range countries {
+		if f.Region != "" && !strings.EqualFold(c.Region, f.Region) {
+			continue
+		}
+		if f.Subregion != "" && !strings.EqualFold(c.Subregion, f.Subregion) {
+			continue
+		}
+		if f.Currency != "" && !strings.EqualFold(c.Currency, f.Currency) {
+			continue
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+// Suggestion is one fuzzy-match result from Suggest.
+type Suggestion struct {
+	Country  Country
+	Distance int
+}
+
+// Suggest returns the countries whose name is closest to query by
+// Levenshtein distance, best match first, limited to limit results. It's
+// meant to drive name-to-code lookup for shell tab-completion.
+func Suggest(query string, limit int) []Suggestion {
+	query = strings.ToLower(query)
+
+	suggestions := make([]Suggestion, len(countries))
+	for i, c := range countries {
+		suggestions[i] = Suggestion{Country: c, Distance: levenshtein(query, strings.ToLower(c.Name))}
+	}
+
+	// Simple insertion sort by distance: the dataset is small (a few
+	// hundred entries at most), so this stays cheap and avoids pulling in
+	// sort.Slice's closure overhead for what is a hot shell-completion path.
+	for i := 1; i < len(suggestions); i++ {
+		for j := i; j > 0 && suggestions[j].Distance < suggestions[j-1].Distance; j-- {
+			suggestions[j], suggestions[j-1] = suggestions[j-1], suggestions[j]
+		}
+	}
+
+	if limit > 0 && limit < len(suggestions) {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions
+}
+
+// levenshtein computes the edit distance between a and b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min3(curr[j-1]+1, prev[j]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+func min3(a, b, c int) int {
+	m := a
+	if b < m {
+		m = b
+	}
+	if c < m {
+		m = c
+	}
+	return m
+}